@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/ai"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var AcceptCmd = &cobra.Command{
+	Use:   "accept [requirement_id] [criterion]",
+	Short: "Add acceptance criteria to a requirement",
+	Long: `Add a Given/When/Then acceptance criterion to a requirement, or list its criteria when none
+is given. With --suggest, candidate criteria are drafted by the AI provider for you to accept or reject.`,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{structuredOutput: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		requirementID := args[0]
+		suggest, _ := cmd.Flags().GetBool("suggest")
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		requirement := project.FindRequirement(requirementID)
+		if requirement == nil {
+			fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", requirementID)
+			os.Exit(1)
+		}
+
+		var added []string
+		if len(args) > 1 {
+			added = append(added, strings.TrimSpace(args[1]))
+		}
+
+		if suggest {
+			if _, err := ai.DefaultProvider(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --suggest requires an AI provider: %v\n", err)
+				os.Exit(1)
+			}
+			yes, _ := cmd.Flags().GetBool("yes")
+			policy := policyAsk
+			if yes {
+				policy = policyAlways
+			}
+			p := newPrompter(progressWriter(outputFormat(cmd)))
+			suggested, err := suggestAcceptanceCriteria(cmd.Context(), p, requirement, policy)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			added = append(added, suggested...)
+		}
+
+		changed := false
+		for _, criterion := range added {
+			if criterion != "" && !slices.Contains(requirement.Acceptance, criterion) {
+				requirement.Acceptance = append(requirement.Acceptance, criterion)
+				changed = true
+			}
+		}
+
+		if changed {
+			requirement.Touch()
+
+			// Save project
+			if err := project.Save(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if output := outputFormat(cmd); output != outputText {
+			printStructured(output, acceptOutput{
+				ID:         requirement.ID,
+				UID:        requirement.UID,
+				Acceptance: nonNil(requirement.Acceptance),
+			})
+			return
+		}
+
+		fmt.Printf("\n%s\n", displayRequirement(requirement))
+		if len(requirement.Acceptance) == 0 {
+			fmt.Println("No acceptance criteria.")
+		}
+		showAcceptance(requirement)
+	},
+}
+
+// acceptOutput is the structured output of `reqd accept`
+type acceptOutput struct {
+	ID         string   `json:"id" yaml:"id"`
+	UID        string   `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Acceptance []string `json:"acceptance" yaml:"acceptance"`
+}
+
+func init() {
+	AcceptCmd.Flags().BoolP("suggest", "s", false, "Draft candidate criteria with the AI provider and choose which to keep")
+	AcceptCmd.Flags().BoolP("yes", "y", false, "Keep every suggested criterion without asking")
+}
+
+// suggestAcceptanceCriteria drafts criteria with the AI provider and returns the ones the user accepts
+func suggestAcceptanceCriteria(ctx context.Context, p *prompter, requirement *types.Requirement, policy string) ([]string, error) {
+	p.printf("Drafting acceptance criteria...\n")
+
+	ctx, stop := withInterrupt(ctx)
+	draft, err := ai.DraftAcceptanceCriteria(ctx, requirement.Text)
+	stop()
+	if err != nil {
+		exitIfCancelled(err)
+		return nil, err
+	}
+
+	if len(draft.Criteria) == 0 {
+		p.printf("No acceptance criteria suggested.\n")
+		return nil, nil
+	}
+
+	var accepted []string
+	for i, criterion := range draft.Criteria {
+		p.printf("\nCandidate %d of %d:\n%s\n\n", i+1, len(draft.Criteria), criterion)
+
+		ok, err := p.confirm("Accept criterion?", policy)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			accepted = append(accepted, criterion)
+		}
+	}
+
+	return accepted, nil
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/techcorrectco/reqd/internal/ai"
+)
+
+func init() {
+	// Report every retried AI request so that flaky networks are visible
+	ai.OnRetry = func(event ai.RetryEvent) {
+		fmt.Fprintf(os.Stderr, "%s request failed (%s), retrying in %s (attempt %d of %d)...\n",
+			event.Provider, event.Reason, event.Wait.Round(time.Millisecond), event.Attempt+1, event.Attempts)
+	}
+}
+
+// withInterrupt returns a context that is cancelled by Ctrl-C. It is used around AI requests
+// so that an interrupt aborts the request and any pending retries instead of killing the process.
+func withInterrupt(ctx context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(ctx, os.Interrupt)
+}
+
+// exitIfCancelled exits when an AI request was aborted with Ctrl-C
+func exitIfCancelled(err error) {
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "\nCancelled.")
+		os.Exit(130)
+	}
+}
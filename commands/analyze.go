@@ -0,0 +1,278 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/ai"
+	"github.com/techcorrectco/reqd/internal/analysis"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// Ways of grouping requirements for `reqd analyze conflicts`
+const (
+	groupBySubtree    = "subtree"
+	groupBySimilarity = "similarity"
+)
+
+// findingHeadings titles the sections of the conflicts report in the order they are printed
+var findingHeadings = []struct {
+	kind    string
+	heading string
+}{
+	{ai.FindingContradiction, "Contradictions"},
+	{ai.FindingOverlap, "Overlaps"},
+	{ai.FindingMissingPrecondition, "Missing preconditions"},
+}
+
+var AnalyzeCmd = &cobra.Command{
+	Use:   "analyze",
+	Short: "Review the requirements as a whole with the AI provider",
+	Long: `Review the requirements as a whole with the AI provider, rather than one requirement at a
+time as validation in 'reqd require' does.`,
+}
+
+var AnalyzeConflictsCmd = &cobra.Command{
+	Use:   "conflicts [requirement_id]",
+	Short: "Find contradictions, overlaps and missing preconditions",
+	Long: `Find contradictions, overlaps and missing preconditions between requirements. Related
+requirements are sent to the AI provider in groups, either by subtree or, with
+--group-by similarity, by the similarity of their embeddings. The report references the
+requirements involved in each finding by ID.
+
+With a requirement ID, only its subtree is analyzed.`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{structuredOutput: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		groupSize, _ := cmd.Flags().GetInt("group-size")
+		minSimilarity, _ := cmd.Flags().GetFloat64("min-similarity")
+		output := outputFormat(cmd)
+
+		if groupBy != groupBySubtree && groupBy != groupBySimilarity {
+			fmt.Fprintf(os.Stderr, "Error: Unknown grouping '%s' (supported: subtree, similarity)\n", groupBy)
+			os.Exit(1)
+		}
+		if groupSize < 2 {
+			fmt.Fprintf(os.Stderr, "Error: --group-size must be at least 2\n")
+			os.Exit(1)
+		}
+		if minSimilarity < 0 || minSimilarity > 1 {
+			fmt.Fprintf(os.Stderr, "Error: --min-similarity must be between 0 and 1\n")
+			os.Exit(1)
+		}
+
+		if _, err := ai.DefaultProvider(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: analyze requires an AI provider: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		scope := project.Requirements
+		if len(args) > 0 {
+			requirement := project.FindRequirement(args[0])
+			if requirement == nil {
+				fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", args[0])
+				os.Exit(1)
+			}
+			scope = []types.Requirement{*requirement}
+		}
+
+		var requirements []*types.Requirement
+		for i := range scope {
+			scope[i].Walk(func(req *types.Requirement) {
+				requirements = append(requirements, req)
+			})
+		}
+
+		// Send progress to stderr when stdout is reserved for structured output
+		p := newPrompter(progressWriter(output))
+
+		var groups []analysis.Group
+		if groupBy == groupBySimilarity {
+			groups, err = similarityGroups(cmd, p, requirements, minSimilarity, groupSize)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			groups = analysis.SubtreeGroups(scope, groupSize)
+		}
+
+		report := conflictsOutput{Requirements: len(requirements), Groups: len(groups), Findings: []findingOutput{}}
+		seen := make(map[string]bool)
+		for i, group := range groups {
+			p.printf("Analyzing group %d of %d (%d requirements)...\n", i+1, len(groups), len(group))
+
+			ctx, stop := withInterrupt(cmd.Context())
+			response, err := ai.FindConflicts(ctx, group)
+			stop()
+			if err != nil {
+				exitIfCancelled(err)
+				fmt.Fprintf(os.Stderr, "Warning: group %d: %v\n", i+1, err)
+				report.Failed++
+				continue
+			}
+
+			for _, finding := range response.Findings {
+				if output, ok := findingOutputFor(finding, group); ok && !seen[output.key()] {
+					seen[output.key()] = true
+					report.Findings = append(report.Findings, output)
+				}
+			}
+		}
+
+		if report.Failed > 0 && report.Failed == len(groups) {
+			fmt.Fprintf(os.Stderr, "Error: analysis failed for every group\n")
+			os.Exit(1)
+		}
+
+		slices.SortStableFunc(report.Findings, func(a, b findingOutput) int {
+			return findingRank(a.Type) - findingRank(b.Type)
+		})
+
+		if output != outputText {
+			printStructured(output, report)
+			return
+		}
+		printConflicts(report)
+	},
+}
+
+func init() {
+	AnalyzeConflictsCmd.Flags().String("group-by", groupBySubtree, "How to group related requirements: subtree or similarity")
+	AnalyzeConflictsCmd.Flags().Int("group-size", 20, "Maximum number of requirements sent to the AI provider at once")
+	AnalyzeConflictsCmd.Flags().Float64("min-similarity", 0.75, "Similarity from 0 to 1 at which requirements are grouped by --group-by similarity")
+
+	AnalyzeCmd.AddCommand(AnalyzeConflictsCmd)
+}
+
+// similarityGroups groups requirements by the similarity of the embeddings of their text
+func similarityGroups(cmd *cobra.Command, p *prompter, requirements []*types.Requirement, minSimilarity float64, groupSize int) ([]analysis.Group, error) {
+	texts := make([]string, len(requirements))
+	for i, req := range requirements {
+		texts[i] = req.Text
+	}
+
+	p.printf("Computing embeddings...\n")
+	ctx, stop := withInterrupt(cmd.Context())
+	vectors, err := ai.Embed(ctx, texts)
+	stop()
+	if err != nil {
+		exitIfCancelled(err)
+		return nil, err
+	}
+
+	similarity := func(i, j int) float64 {
+		return ai.CosineSimilarity(vectors[i], vectors[j])
+	}
+	return analysis.SimilarityGroups(requirements, similarity, minSimilarity, groupSize), nil
+}
+
+// conflictsOutput is the report of `reqd analyze conflicts`
+type conflictsOutput struct {
+	Requirements int             `json:"requirements" yaml:"requirements"`
+	Groups       int             `json:"groups" yaml:"groups"`
+	Failed       int             `json:"failed,omitempty" yaml:"failed,omitempty"`
+	Findings     []findingOutput `json:"findings" yaml:"findings"`
+}
+
+// findingOutput is a finding with the requirements it references
+type findingOutput struct {
+	Type         string               `json:"type" yaml:"type"`
+	Description  string               `json:"description" yaml:"description"`
+	Requirements []findingRequirement `json:"requirements" yaml:"requirements"`
+}
+
+// findingRequirement is a requirement referenced by a finding
+type findingRequirement struct {
+	ID   string `json:"id" yaml:"id"`
+	UID  string `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Text string `json:"text" yaml:"text"`
+}
+
+// findingOutputFor resolves the IDs referenced by a finding within its group, dropping IDs the
+// AI provider made up. It reports false when no referenced requirement is left.
+func findingOutputFor(finding ai.Finding, group analysis.Group) (findingOutput, bool) {
+	output := findingOutput{
+		Type:        strings.ToLower(strings.TrimSpace(finding.Type)),
+		Description: finding.Description,
+	}
+	for _, id := range finding.Requirements {
+		index := slices.IndexFunc(group, func(req *types.Requirement) bool { return req.ID == id })
+		if index < 0 || slices.ContainsFunc(output.Requirements, func(r findingRequirement) bool { return r.ID == id }) {
+			continue
+		}
+		req := group[index]
+		output.Requirements = append(output.Requirements, findingRequirement{ID: req.ID, UID: req.UID, Text: req.Text})
+	}
+	return output, len(output.Requirements) > 0
+}
+
+// key identifies a finding by its type and requirements, so that the same problem found in
+// overlapping groups is reported once
+func (f findingOutput) key() string {
+	ids := make([]string, len(f.Requirements))
+	for i, req := range f.Requirements {
+		ids[i] = req.ID
+	}
+	slices.Sort(ids)
+	return f.Type + " " + strings.Join(ids, " ")
+}
+
+// findingRank orders findings by the sections of the report, with unknown types last
+func findingRank(kind string) int {
+	for i, section := range findingHeadings {
+		if section.kind == kind {
+			return i
+		}
+	}
+	return len(findingHeadings)
+}
+
+// printConflicts prints the findings in one section per type
+func printConflicts(report conflictsOutput) {
+	fmt.Printf("\nAnalyzed %s in %s.\n", countOf(report.Requirements, "requirement"), countOf(report.Groups, "group"))
+	if len(report.Findings) == 0 {
+		fmt.Println("No conflicts found.")
+		return
+	}
+
+	heading := ""
+	for _, finding := range report.Findings {
+		section := "Other findings"
+		if rank := findingRank(finding.Type); rank < len(findingHeadings) {
+			section = findingHeadings[rank].heading
+		}
+		if section != heading {
+			fmt.Printf("\n%s:\n", section)
+			heading = section
+		}
+
+		ids := make([]string, len(finding.Requirements))
+		for i, req := range finding.Requirements {
+			ids[i] = req.ID
+		}
+		fmt.Printf("\n- %s: %s\n", strings.Join(ids, ", "), finding.Description)
+		for _, req := range finding.Requirements {
+			fmt.Printf("    %s\n", displayRequirement(&types.Requirement{ID: req.ID, UID: req.UID, Text: req.Text}))
+		}
+	}
+}
+
+// countOf formats a count with the singular or plural of noun, e.g. "1 group" or "3 groups"
+func countOf(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/cache"
+	"github.com/techcorrectco/reqd/internal/config"
+)
+
+var CacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the AI response cache",
+	Long: `Manage the on-disk cache of AI validations and parent proposals. Responses are cached by
+provider, base URL, model, temperature, prompt and input, so repeating a request costs neither
+time nor money.`,
+}
+
+var CacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached AI responses",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		responseCache := openResponseCache()
+
+		removed, err := responseCache.Clear()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to clear cache: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Removed %d cached responses from %s\n", removed, responseCache.Dir)
+	},
+}
+
+var CacheStatsCmd = &cobra.Command{
+	Use:         "stats",
+	Short:       "Show how many AI responses are cached",
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{structuredOutput: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		responseCache := openResponseCache()
+
+		stats, err := responseCache.Stats()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read cache: %v\n", err)
+			os.Exit(1)
+		}
+
+		if output := outputFormat(cmd); output != outputText {
+			printStructured(output, cacheStatsOutput{
+				Location: responseCache.Dir,
+				TTL:      responseCache.TTL.String(),
+				Stats:    stats,
+			})
+			return
+		}
+
+		fmt.Printf("Location: %s\n", responseCache.Dir)
+		fmt.Printf("TTL:      %s\n", responseCache.TTL)
+		fmt.Printf("Entries:  %d (%d expired)\n", stats.Entries, stats.Expired)
+		fmt.Printf("Size:     %d bytes\n", stats.Bytes)
+		if stats.Entries > 0 {
+			fmt.Printf("Oldest:   %s\n", stats.Oldest.Format("2006-01-02 15:04:05"))
+			fmt.Printf("Newest:   %s\n", stats.Newest.Format("2006-01-02 15:04:05"))
+		}
+	},
+}
+
+// cacheStatsOutput is the structured output of `reqd cache stats`
+type cacheStatsOutput struct {
+	Location    string `json:"location" yaml:"location"`
+	TTL         string `json:"ttl" yaml:"ttl"`
+	cache.Stats `yaml:",inline"`
+}
+
+func init() {
+	CacheCmd.AddCommand(CacheClearCmd)
+	CacheCmd.AddCommand(CacheStatsCmd)
+}
+
+// openResponseCache opens the AI response cache with the configured TTL
+func openResponseCache() *cache.Cache {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to locate cache directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	return cache.New(dir, cfg.CacheTTL)
+}
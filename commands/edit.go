@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var EditCmd = &cobra.Command{
+	Use:     "edit [requirement_id] [new text]",
+	Aliases: []string{"e"},
+	Short:   "Revise the text of an existing requirement",
+	Long: `Revise the text, priority or metadata of an existing requirement. When neither new text nor another
+change is given, the current text is opened in $EDITOR. Revised text goes through the same
+validation as new requirements.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		requirementID := args[0]
+		noValidate, _ := cmd.Flags().GetBool("no-validate")
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		requirement := project.FindRequirement(requirementID)
+		if requirement == nil {
+			fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", requirementID)
+			os.Exit(1)
+		}
+
+		changed := false
+
+		if cmd.Flags().Changed("priority") {
+			priority, _ := cmd.Flags().GetString("priority")
+			// An empty priority clears it
+			if priority != "" {
+				if priority, err = types.ParsePriority(priority); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			if priority != requirement.Priority {
+				requirement.Priority = priority
+				changed = true
+			}
+		}
+
+		if applyMetadataFlags(cmd, requirement) {
+			changed = true
+		}
+
+		// Only open the editor when there is nothing else to change
+		if len(args) > 1 || !(cmd.Flags().Changed("priority") || metadataFlagsChanged(cmd)) {
+			var newText string
+			if len(args) > 1 {
+				newText = strings.TrimSpace(args[1])
+			} else {
+				newText, err = editInEditor(requirement.Text)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			if newText == "" {
+				fmt.Fprintf(os.Stderr, "Error: Requirement text cannot be empty\n")
+				os.Exit(1)
+			}
+
+			if newText != requirement.Text {
+				acceptRecommendation, err := getPolicy(cmd, "accept-recommendation")
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+				requirement.Text, _ = reviewRequirement(cmd.Context(), newPrompter(os.Stdout), newText, noValidate, acceptRecommendation)
+				changed = true
+			}
+		}
+
+		if !changed {
+			fmt.Println("No changes made.")
+			return
+		}
+
+		warnPriorityConflict(requirement.Priority, requirement.Text)
+		requirement.Touch()
+
+		// Save project
+		if err := project.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\n%s\n", displayRequirement(requirement))
+	},
+}
+
+func init() {
+	EditCmd.Flags().BoolP("no-validate", "V", false, "Skip AI validation of the revised requirement")
+	EditCmd.Flags().String("priority", "", "Set the MoSCoW priority: must, should, could or wont (empty to clear)")
+	addMetadataFlags(EditCmd)
+	EditCmd.Flags().BoolP("yes", "y", false, "Accept the recommended text without asking")
+	addPolicyFlag(EditCmd, "accept-recommendation", "Whether to accept the recommended text")
+}
+
+// editInEditor opens the given text in $EDITOR and returns the edited text
+func editInEditor(text string) (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	file, err := os.CreateTemp("", "reqd-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(text + "\n"); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	// EDITOR may include arguments, e.g. "code --wait"
+	parts := strings.Fields(editor)
+	editorCmd := exec.Command(parts[0], append(parts[1:], file.Name())...)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return "", fmt.Errorf("editor exited with error: %w", err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited text: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/formats"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the requirements as a document",
+	Long: `Export the requirements in another format, written to stdout or to the file given with --file.
+
+Supported formats:
+  markdown  A PRD document with the project name as title and one heading (or list item) per requirement
+  html      A self-contained report with a collapsible tree, search box and status and tag filters
+  reqif     A ReqIF document for DOORS, Polarion and other requirements tools
+  csv       One row per requirement with its ID, parent ID, depth, text and metadata, for spreadsheets`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		fileName, _ := cmd.Flags().GetString("file")
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		var export func(w io.Writer) error
+		switch format {
+		case "markdown", "md":
+			toc, _ := cmd.Flags().GetBool("toc")
+			layout, _ := cmd.Flags().GetString("layout")
+			export = func(w io.Writer) error {
+				return formats.WriteMarkdown(w, project, formats.MarkdownOptions{Layout: layout, TOC: toc})
+			}
+		case "html":
+			export = func(w io.Writer) error {
+				return formats.WriteHTML(w, project)
+			}
+		case "reqif":
+			export = func(w io.Writer) error {
+				return formats.WriteReqIF(w, project)
+			}
+		case "csv":
+			export = func(w io.Writer) error {
+				return formats.WriteCSV(w, project)
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Error: Unknown export format '%s' (supported: markdown, html, reqif, csv)\n", format)
+			os.Exit(1)
+		}
+
+		if err := writeExport(fileName, export); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if fileName != "" {
+			fmt.Printf("Exported requirements to %s\n", fileName)
+		}
+	},
+}
+
+func init() {
+	ExportCmd.Flags().StringP("format", "f", "markdown", "Export format: markdown, html, reqif or csv")
+	ExportCmd.Flags().String("file", "", "Write to this file instead of stdout")
+	ExportCmd.Flags().Bool("toc", false, "Markdown: add a table of contents")
+	ExportCmd.Flags().String("layout", formats.MarkdownHeadings, "Markdown: render requirements as headings or as a nested list")
+}
+
+// writeExport runs export against the named file, or stdout when no file is given
+func writeExport(fileName string, export func(w io.Writer) error) error {
+	if fileName == "" {
+		return export(os.Stdout)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	if err := export(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
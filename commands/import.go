@@ -0,0 +1,430 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/ai"
+	"github.com/techcorrectco/reqd/internal/formats"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// defaultImportConcurrency is the number of validation requests in flight during an import
+const defaultImportConcurrency = 4
+
+var ImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import requirements from a text or Markdown file",
+	Long: `Import requirements from a file.
+
+Supported formats:
+  text   One requirement per line. Markdown bullet and numbered lists are supported; indentation
+         determines the hierarchy. Blank lines, headings, horizontal rules and code blocks are
+         skipped. When an AI provider is configured, every requirement is validated first and a
+         summary of the recommended changes is shown before anything is written.
+  reqif  A ReqIF document as exchanged with DOORS, Polarion and other requirements tools. The
+         SPEC-HIERARCHY becomes the requirement tree; metadata and links are kept.
+  csv    Rows as written by 'reqd export --format csv'. The parent column determines the
+         hierarchy. With --merge, the rows are applied as edits instead: rows are matched to
+         requirements by UID (or ID), new rows are added, and requirements without a row are
+         removed. The changes are listed before anything is written.
+
+The format is detected from the file extension (.reqif for ReqIF, .csv for CSV, anything else is
+text) unless --format is given.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		fileName := args[0]
+		format, _ := cmd.Flags().GetString("format")
+		parentID, _ := cmd.Flags().GetString("parent")
+		noValidate, _ := cmd.Flags().GetBool("no-validate")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		merge, _ := cmd.Flags().GetBool("merge")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		acceptRecommendations, err := getPolicy(cmd, "accept-recommendations")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if concurrency < 1 {
+			fmt.Fprintf(os.Stderr, "Error: --concurrency must be at least 1\n")
+			os.Exit(1)
+		}
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		if parentID != "" {
+			parent := project.FindRequirement(parentID)
+			if parent == nil {
+				fmt.Fprintf(os.Stderr, "Error: Parent requirement '%s' not found\n", parentID)
+				os.Exit(1)
+			}
+			parentID = parent.ID
+		}
+
+		if format == "" {
+			format = importFormat(fileName)
+		}
+		if merge && (format != "csv" || parentID != "") {
+			fmt.Fprintf(os.Stderr, "Error: --merge only works with CSV files and without --parent\n")
+			os.Exit(1)
+		}
+
+		file, err := os.Open(fileName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+
+		var imported int
+		switch format {
+		case "text", "markdown", "md":
+			items, err := formats.ParseOutline(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: No requirements found in %s\n", fileName)
+				os.Exit(1)
+			}
+
+			// Auto-skip validation if no AI provider is configured and --no-validate wasn't explicitly used
+			if !noValidate && ai.Available() {
+				p := newPrompter(os.Stdout)
+				if err := reviewImport(cmd.Context(), p, items, concurrency, acceptRecommendations); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			fmt.Println()
+			imported = importOutline(project, parentID, items)
+		case "reqif":
+			document, err := formats.ReadReqIF(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(document.Requirements) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: No requirements found in %s\n", fileName)
+				os.Exit(1)
+			}
+			imported = importRequirements(project, parentID, document.Requirements)
+		case "csv":
+			rows, err := formats.ReadCSV(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if merge {
+				mergeCSVImport(project, rows, dryRun, yes)
+				return
+			}
+			if len(rows) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: No requirements found in %s\n", fileName)
+				os.Exit(1)
+			}
+			imported = importRequirements(project, parentID, formats.CSVTree(rows))
+		default:
+			fmt.Fprintf(os.Stderr, "Error: Unknown import format '%s' (supported: text, reqif, csv)\n", format)
+			os.Exit(1)
+		}
+
+		if dryRun {
+			fmt.Printf("\nDry run: %d requirements would be imported\n", imported)
+			return
+		}
+
+		// Save project
+		if err := project.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("\nImported %d requirements\n", imported)
+	},
+}
+
+func init() {
+	ImportCmd.Flags().StringP("format", "f", "", "Import format: text, reqif or csv (default: from the file extension)")
+	ImportCmd.Flags().StringP("parent", "p", "", "Import under this requirement instead of at the top level")
+	ImportCmd.Flags().BoolP("no-validate", "V", false, "Skip AI validation of the imported requirements")
+	ImportCmd.Flags().Int("concurrency", defaultImportConcurrency, "Maximum number of validation requests in flight")
+	ImportCmd.Flags().BoolP("yes", "y", false, "Accept all recommended changes, or the changes of a merge, without asking")
+	addPolicyFlag(ImportCmd, "accept-recommendations", "Whether to accept the recommended changes")
+	ImportCmd.Flags().Bool("merge", false, "CSV: apply the rows as edits to the existing requirements")
+	ImportCmd.Flags().Bool("dry-run", false, "Show what would be imported without saving")
+}
+
+// importValidation is the validation outcome of one outline item
+type importValidation struct {
+	item     *formats.OutlineItem
+	response *ai.ValidationResponse
+	err      error
+}
+
+// reviewImport validates every item with at most concurrency requests in flight, prints a summary
+// of the recommended changes and applies them to the items when accepted
+func reviewImport(ctx context.Context, p *prompter, items []formats.OutlineItem, concurrency int, policy string) error {
+	var pending []*formats.OutlineItem
+	var collect func(items []formats.OutlineItem)
+	collect = func(items []formats.OutlineItem) {
+		for i := range items {
+			pending = append(pending, &items[i])
+			collect(items[i].Children)
+		}
+	}
+	collect(items)
+
+	p.printf("Reviewing %d requirements...\n", len(pending))
+
+	ctx, stop := withInterrupt(ctx)
+	results := validateConcurrently(ctx, pending, concurrency)
+	stop()
+
+	var changes []importValidation
+	failed := 0
+	for _, result := range results {
+		exitIfCancelled(result.err)
+		switch {
+		case result.err != nil:
+			failed++
+			fmt.Fprintf(os.Stderr, "Warning: line %d: %v\n", result.item.Line, result.err)
+		case result.response.Recommended != "" && result.response.Recommended != result.item.Text:
+			changes = append(changes, result)
+		}
+	}
+
+	for _, change := range changes {
+		p.printf("\nLine %d:\n%s\n", change.item.Line, change.item.Text)
+		for _, problem := range change.response.Problems {
+			p.printf("- %s\n", problem)
+		}
+		p.printf("Recommended:\n%s\n", change.response.Recommended)
+	}
+
+	p.printf("\n%d of %d requirements have recommended changes", len(changes), len(pending))
+	if failed > 0 {
+		p.printf(", %d could not be validated", failed)
+	}
+	p.printf(".\n")
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	accepted, err := p.confirm(fmt.Sprintf("Accept all %d recommended changes?", len(changes)), policy)
+	if err != nil || !accepted {
+		return err
+	}
+	for _, change := range changes {
+		change.item.Text = change.response.Recommended
+	}
+	return nil
+}
+
+// validateConcurrently validates the items with at most concurrency requests in flight and
+// returns the results in the order of the items
+func validateConcurrently(ctx context.Context, items []*formats.OutlineItem, concurrency int) []importValidation {
+	results := make([]importValidation, len(items))
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			response, err := ai.ValidateRequirement(ctx, item.Text)
+			results[i] = importValidation{item: item, response: response, err: err}
+
+			mu.Lock()
+			done++
+			fmt.Fprintf(os.Stderr, "\rValidated %d of %d", done, len(items))
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	fmt.Fprintln(os.Stderr)
+
+	return results
+}
+
+// importOutline adds the outline under parentID ("" for top level), generating IDs and UIDs the
+// same way as `reqd require`, and returns the number of requirements added
+func importOutline(project *types.Project, parentID string, items []formats.OutlineItem) int {
+	count := 0
+	for _, item := range items {
+		req := createRequirement(strings.TrimSpace(item.Text), parentID, project)
+		if parentID == "" {
+			project.Requirements = append(project.Requirements, req)
+		} else {
+			addChildRequirement(project.Requirements, parentID, req)
+		}
+		fmt.Println(displayRequirement(&req))
+
+		count += 1 + importOutline(project, req.ID, item.Children)
+	}
+	return count
+}
+
+// importFormat detects the import format from the file extension
+func importFormat(fileName string) string {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".reqif":
+		return "reqif"
+	case ".csv":
+		return "csv"
+	}
+	return "text"
+}
+
+// importRequirements adds requirement trees read from another tool under parentID ("" for top
+// level) and returns the number of requirements added. Positional IDs are regenerated. UIDs are
+// kept unless they are missing or already in use, in which case new ones are assigned and links
+// between the imported requirements are updated to match. Links to requirements outside the
+// import are dropped.
+func importRequirements(project *types.Project, parentID string, requirements []types.Requirement) int {
+	// Keep the UIDs that are free, reserving them so that NewUID does not hand them out again
+	kept := make(map[*types.Requirement]bool)
+	seen := make(map[string]bool)
+	walkRequirements(requirements, func(req *types.Requirement) {
+		uid := strings.ToUpper(req.UID)
+		if types.IsUID(uid) && !seen[uid] && project.FindRequirement(uid) == nil {
+			kept[req] = true
+			seen[uid] = true
+			project.ReserveUID(uid)
+		}
+	})
+
+	// Replace the other UIDs, including identifiers of foreign ReqIF objects, and remember them
+	// so that links can follow
+	renamed := make(map[string]string)
+	imported := make(map[string]bool)
+	walkRequirements(requirements, func(req *types.Requirement) {
+		if !kept[req] {
+			uid := project.NewUID()
+			if req.UID != "" {
+				renamed[uidKey(req.UID)] = uid
+			}
+			req.UID = uid
+		}
+		imported[req.UID] = true
+	})
+
+	walkRequirements(requirements, func(req *types.Requirement) {
+		var links []types.Link
+		for _, link := range req.Links {
+			if uid, ok := renamed[uidKey(link.Target)]; ok {
+				link.Target = uid
+			}
+			if imported[link.Target] {
+				links = append(links, link)
+			}
+		}
+		req.Links = links
+	})
+
+	// Keep the times recorded by the source file; requirements without them are new to the project
+	walkRequirements(requirements, func(req *types.Requirement) {
+		if req.Updated.IsZero() {
+			req.Touch()
+		}
+		if req.Created.IsZero() {
+			req.Created = req.Updated
+		}
+	})
+
+	count := 0
+	for _, req := range requirements {
+		req.SetID(nextRequirementID(parentID, project))
+		if parentID == "" {
+			project.Requirements = append(project.Requirements, req)
+		} else {
+			addChildRequirement(project.Requirements, parentID, req)
+		}
+		req.Walk(func(req *types.Requirement) {
+			fmt.Println(displayRequirement(req))
+			count++
+		})
+	}
+	return count
+}
+
+// uidKey normalizes the case of reqd UIDs, which are case-insensitive, and keeps other
+// identifiers as they are
+func uidKey(uid string) string {
+	if types.IsUID(uid) {
+		return strings.ToUpper(uid)
+	}
+	return uid
+}
+
+// mergeCSVImport applies CSV rows as edits to the project after listing them and asking for
+// confirmation
+func mergeCSVImport(project *types.Project, rows []formats.CSVRow, dryRun, yes bool) {
+	project.AssignUIDs()
+
+	merge, err := planCSVMerge(project, rows)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if merge.empty() {
+		fmt.Println("No changes.")
+		return
+	}
+
+	merge.print(project)
+	if dryRun {
+		return
+	}
+
+	policy := policyAsk
+	if yes {
+		policy = policyAlways
+	}
+	accepted, err := newPrompter(os.Stdout).confirm("\nApply these changes?", policy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !accepted {
+		fmt.Println("No changes made.")
+		return
+	}
+
+	merge.apply(project)
+
+	// Save project
+	if err := project.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Merged changes into requirements.yaml")
+}
+
+// walkRequirements calls fn for each requirement and its descendants in tree order
+func walkRequirements(requirements []types.Requirement, fn func(req *types.Requirement)) {
+	for i := range requirements {
+		requirements[i].Walk(fn)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/ai"
 	"github.com/techcorrectco/reqd/internal/types"
 	"gopkg.in/yaml.v3"
 )
@@ -51,10 +52,11 @@ var InitCmd = &cobra.Command{
 
 		fmt.Println("Created requirements.yaml")
 
-		// Check for OpenAI API key and inform user
-		if os.Getenv("OPENAI_API_KEY") == "" {
+		// Check for an AI provider and inform user
+		if !ai.Available() {
 			fmt.Println("\nFor requirement validation, set your OpenAI API key:")
 			fmt.Println("  export OPENAI_API_KEY=\"your-api-key-here\"")
+			fmt.Println("\nor select another provider with REQD_PROVIDER (anthropic, ollama, llamacpp).")
 			fmt.Println("\n--no-validate will be used when adding requirements")
 		}
 	},
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var LinkCmd = &cobra.Command{
+	Use:   "link [from_id] [type] [to_id]",
+	Short: "Link two requirements",
+	Long: `Create a typed link from one requirement to another. Supported types are depends-on,
+conflicts-with, refines and duplicates. Links refer to their target by UID, so they survive
+renumbering.`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLink(args, true)
+	},
+}
+
+var UnlinkCmd = &cobra.Command{
+	Use:   "unlink [from_id] [type] [to_id]",
+	Short: "Remove a link between two requirements",
+	Long:  `Remove a typed link from one requirement to another.`,
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		runLink(args, false)
+	},
+}
+
+// runLink adds or removes the link described by "<from> <type> <to>" arguments
+func runLink(args []string, add bool) {
+	fromID, toID := args[0], args[2]
+
+	linkType, err := types.ParseLinkType(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Load existing project
+	project, err := types.LoadProject()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+		os.Exit(1)
+	}
+
+	from := project.FindRequirement(fromID)
+	if from == nil {
+		fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", fromID)
+		os.Exit(1)
+	}
+
+	to := project.FindRequirement(toID)
+	if to == nil {
+		fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", toID)
+		os.Exit(1)
+	}
+
+	if from == to {
+		fmt.Fprintf(os.Stderr, "Error: A requirement cannot be linked to itself\n")
+		os.Exit(1)
+	}
+
+	// Links refer to their target by UID
+	if to.UID == "" {
+		to.UID = project.NewUID()
+	}
+
+	var changed bool
+	if add {
+		changed = from.AddLink(linkType, to.UID)
+	} else {
+		changed = from.RemoveLink(linkType, to.UID)
+	}
+
+	if !changed {
+		if add {
+			fmt.Printf("%s already %s %s\n", from.ID, linkType, to.ID)
+		} else {
+			fmt.Printf("No %s link from %s to %s\n", linkType, from.ID, to.ID)
+		}
+		return
+	}
+
+	from.Touch()
+
+	// Save project
+	if err := project.Save(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+		os.Exit(1)
+	}
+
+	if add {
+		fmt.Printf("Linked %s %s %s\n", from.ID, linkType, to.ID)
+	} else {
+		fmt.Printf("Unlinked %s %s %s\n", from.ID, linkType, to.ID)
+	}
+}
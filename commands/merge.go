@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/techcorrectco/reqd/internal/formats"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// csvMerge is the set of edits a CSV file makes to the project
+type csvMerge struct {
+	added   []csvAddition
+	changed []csvChange
+	removed []*types.Requirement
+}
+
+// csvAddition is a row without a matching requirement, added under the parent with the given UID
+type csvAddition struct {
+	row       formats.CSVRow
+	parentUID string
+}
+
+// csvChange is a row that edits the columns of an existing requirement
+type csvChange struct {
+	row     formats.CSVRow
+	uid     string
+	columns []string
+}
+
+// planCSVMerge matches the rows to requirements by UID, or by ID when the row has no UID, and
+// works out which requirements are added, changed and removed. Rows without a match are added
+// under the existing requirement named in their parent column; requirements without a row are
+// removed. Status changes must follow the project's transition graph, as with `reqd status`.
+// Every requirement in the project must have a UID.
+func planCSVMerge(project *types.Project, rows []formats.CSVRow) (*csvMerge, error) {
+	merge := &csvMerge{}
+	statuses := project.Statuses()
+
+	// Links may name their target by ID or UID; store them by UID like `reqd link` does
+	for i := range rows {
+		row := &rows[i]
+		for j, link := range row.Requirement.Links {
+			target := project.FindRequirement(link.Target)
+			if target == nil {
+				return nil, fmt.Errorf("line %d: link target '%s' not found", row.Line, link.Target)
+			}
+			row.Requirement.Links[j].Target = target.UID
+		}
+	}
+
+	matched := make(map[string]bool)
+	var illegalTransitions []error
+	for _, row := range rows {
+		if row.Requirement.Status != "" && !slices.Contains(statuses, row.Requirement.Status) {
+			return nil, fmt.Errorf("line %d: unknown status '%s' (known statuses: %s)", row.Line, row.Requirement.Status, strings.Join(statuses, ", "))
+		}
+
+		var req *types.Requirement
+		switch {
+		case row.UID != "":
+			req = project.FindRequirement(row.UID)
+		case row.ID != "":
+			req = project.FindRequirement(row.ID)
+		}
+
+		if req == nil {
+			addition := csvAddition{row: row}
+			if row.Parent != "" {
+				parent := project.FindRequirement(row.Parent)
+				if parent == nil {
+					return nil, fmt.Errorf("line %d: parent requirement '%s' not found (new requirements can only be added under existing ones)", row.Line, row.Parent)
+				}
+				addition.parentUID = parent.UID
+			}
+			merge.added = append(merge.added, addition)
+			continue
+		}
+
+		if matched[req.UID] {
+			return nil, fmt.Errorf("line %d: requirement '%s' is listed more than once", row.Line, req.ID)
+		}
+		matched[req.UID] = true
+
+		if row.HasColumn(formats.ColumnParent) && project.FindRequirement(row.Parent) != project.FindRequirement(parentOf(req.ID)) {
+			return nil, fmt.Errorf("line %d: cannot change the parent of '%s' by import; use 'reqd move'", row.Line, req.ID)
+		}
+
+		edited := *req
+		if columns := row.Apply(&edited); len(columns) > 0 {
+			merge.changed = append(merge.changed, csvChange{row: row, uid: req.UID, columns: columns})
+		}
+
+		if from, to := req.CurrentStatus(), edited.CurrentStatus(); from != to {
+			if err := project.ValidateTransition(from, to); err != nil {
+				illegalTransitions = append(illegalTransitions, fmt.Errorf("line %d: %s: %w", row.Line, req.ID, err))
+			}
+		}
+	}
+
+	// Report every illegal status change at once so that the file can be fixed in one go
+	if len(illegalTransitions) > 0 {
+		return nil, errors.Join(illegalTransitions...)
+	}
+
+	removed := make(map[string]bool)
+	project.Walk(func(req *types.Requirement) {
+		if !matched[req.UID] {
+			merge.removed = append(merge.removed, req)
+			removed[req.UID] = true
+		}
+	})
+
+	// Requirements that stay must not lose their parent or link targets
+	for _, row := range rows {
+		for _, link := range row.Requirement.Links {
+			if removed[link.Target] {
+				return nil, fmt.Errorf("line %d: link target '%s' is removed by the same import", row.Line, link.Target)
+			}
+		}
+	}
+	for _, addition := range merge.added {
+		if removed[addition.parentUID] {
+			return nil, fmt.Errorf("line %d: parent requirement '%s' is removed by the same import", addition.row.Line, addition.row.Parent)
+		}
+	}
+	var orphaned error
+	project.Walk(func(req *types.Requirement) {
+		if orphaned == nil && matched[req.UID] {
+			if parent := project.FindRequirement(parentOf(req.ID)); parent != nil && removed[parent.UID] {
+				orphaned = fmt.Errorf("cannot remove '%s': its child '%s' is still listed", parent.ID, req.ID)
+			}
+		}
+	})
+	if orphaned != nil {
+		return nil, orphaned
+	}
+
+	return merge, nil
+}
+
+// empty reports whether the merge changes nothing
+func (m *csvMerge) empty() bool {
+	return len(m.added) == 0 && len(m.changed) == 0 && len(m.removed) == 0
+}
+
+// print lists the planned edits followed by a summary line
+func (m *csvMerge) print(project *types.Project) {
+	if len(m.added) > 0 {
+		fmt.Println("Added:")
+		for _, addition := range m.added {
+			parent := displayParent(parentIDOf(project, addition.parentUID))
+			fmt.Printf("  + under %s: %s\n", parent, addition.row.Requirement.Text)
+		}
+	}
+	if len(m.changed) > 0 {
+		fmt.Println("Changed:")
+		for _, change := range m.changed {
+			req := project.FindRequirement(change.uid)
+			fmt.Printf("  ~ %s [%s] (%s)\n", req.ID, req.UID, strings.Join(change.columns, ", "))
+		}
+	}
+	if len(m.removed) > 0 {
+		fmt.Println("Removed:")
+		for _, req := range m.removed {
+			fmt.Printf("  - %s\n", displayRequirement(req))
+		}
+	}
+	fmt.Printf("\n%d added, %d changed, %d removed\n", len(m.added), len(m.changed), len(m.removed))
+}
+
+// apply performs the planned edits
+func (m *csvMerge) apply(project *types.Project) {
+	// Collect the UIDs first; adding requirements invalidates the pointers
+	var removedUIDs []string
+	for _, req := range m.removed {
+		removedUIDs = append(removedUIDs, req.UID)
+	}
+
+	for _, change := range m.changed {
+		req := project.FindRequirement(change.uid)
+		change.row.Apply(req)
+		req.Touch()
+	}
+
+	for _, addition := range m.added {
+		parentID := parentIDOf(project, addition.parentUID)
+		values := addition.row.Requirement
+
+		req := createRequirement(values.Text, parentID, project)
+		req.Status = values.Status
+		req.Priority = values.Priority
+		req.Tags = values.Tags
+		req.Rationale = values.Rationale
+		req.Source = values.Source
+		req.Owner = values.Owner
+		req.Acceptance = values.Acceptance
+		req.Links = values.Links
+
+		if parentID == "" {
+			project.Requirements = append(project.Requirements, req)
+		} else {
+			addChildRequirement(project.Requirements, parentID, req)
+		}
+	}
+
+	// Look up the current ID of each removed requirement as siblings are renumbered;
+	// descendants of a removed requirement are already gone
+	for _, uid := range removedUIDs {
+		if req := project.FindRequirement(uid); req != nil {
+			project.RemoveRequirement(req.ID, true)
+		}
+	}
+	project.RemoveLinksTo(removedUIDs)
+}
+
+// parentIDOf returns the current ID of the requirement with the given UID ("" for top level)
+func parentIDOf(project *types.Project, uid string) string {
+	if uid == "" {
+		return ""
+	}
+	return project.FindRequirement(uid).ID
+}
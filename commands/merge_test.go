@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/techcorrectco/reqd/internal/formats"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+func testMergeProject() *types.Project {
+	return &types.Project{
+		Name:    "test",
+		LastUID: 4,
+		Requirements: []types.Requirement{
+			{ID: "1", UID: "REQ-0001", Text: "The system MUST log in users", Status: types.StatusApproved, Children: []types.Requirement{
+				{ID: "1.1", UID: "REQ-0002", Text: "The system MUST lock accounts"},
+			}},
+			{ID: "2", UID: "REQ-0003", Text: "The system MUST log out users"},
+			{ID: "3", UID: "REQ-0004", Text: "The system MUST export data"},
+		},
+	}
+}
+
+// treeOf lists the ID, UID and text of every requirement in tree order
+func treeOf(project *types.Project) []string {
+	var tree []string
+	project.Walk(func(req *types.Requirement) {
+		tree = append(tree, req.ID+" "+req.UID+" "+req.Text)
+	})
+	return tree
+}
+
+func TestPlanCSVMerge(t *testing.T) {
+	tests := []struct {
+		name        string
+		csv         string
+		wantAdded   []string
+		wantChanged []string
+		wantRemoved []string
+		wantErr     []string
+		wantTree    []string
+	}{
+		{
+			name: "added, changed and removed rows",
+			csv: "id,uid,parent,text\n" +
+				"1,REQ-0001,,The system MUST log in registered users\n" +
+				"1.1,REQ-0002,1,The system MUST lock accounts\n" +
+				",,1,The system MUST log failed logins\n" +
+				"2,REQ-0003,,The system MUST log out users\n",
+			wantAdded:   []string{"The system MUST log failed logins"},
+			wantChanged: []string{"REQ-0001 text"},
+			wantRemoved: []string{"REQ-0004"},
+			wantTree: []string{
+				"1 REQ-0001 The system MUST log in registered users",
+				"1.1 REQ-0002 The system MUST lock accounts",
+				"1.2 REQ-0005 The system MUST log failed logins",
+				"2 REQ-0003 The system MUST log out users",
+			},
+		},
+		{
+			name: "unknown parent",
+			csv: "id,uid,parent,text\n" +
+				"1,REQ-0001,,The system MUST log in users\n" +
+				",,9,The system MUST log failed logins\n",
+			wantErr: []string{"line 3: parent requirement '9' not found"},
+		},
+		{
+			name: "row without UID matched by ID",
+			csv: "id,text\n" +
+				"1,The system MUST log in users\n" +
+				"1.1,The system MUST lock accounts\n" +
+				"2,The system MUST log out all sessions\n" +
+				"3,The system MUST export data\n",
+			wantChanged: []string{"REQ-0003 text"},
+			wantTree: []string{
+				"1 REQ-0001 The system MUST log in users",
+				"1.1 REQ-0002 The system MUST lock accounts",
+				"2 REQ-0003 The system MUST log out all sessions",
+				"3 REQ-0004 The system MUST export data",
+			},
+		},
+		{
+			name: "illegal status transitions",
+			csv: "uid,text,status\n" +
+				"REQ-0001,The system MUST log in users,draft\n" +
+				"REQ-0002,The system MUST lock accounts,verified\n" +
+				"REQ-0003,The system MUST log out users,proposed\n" +
+				"REQ-0004,The system MUST export data,implemented\n",
+			wantErr: []string{"line 2: 1: ", "line 3: 1.1: ", "line 5: 3: "},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := testMergeProject()
+			rows, err := formats.ReadCSV(strings.NewReader(tt.csv))
+			if err != nil {
+				t.Fatalf("ReadCSV() error = %v", err)
+			}
+
+			merge, err := planCSVMerge(project, rows)
+			if len(tt.wantErr) > 0 {
+				if err == nil {
+					t.Fatal("planCSVMerge() error = nil, want an error")
+				}
+				for _, want := range tt.wantErr {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("planCSVMerge() error = %q, want it to contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("planCSVMerge() error = %v", err)
+			}
+
+			var added, changed, removed []string
+			for _, addition := range merge.added {
+				added = append(added, addition.row.Requirement.Text)
+			}
+			for _, change := range merge.changed {
+				changed = append(changed, change.uid+" "+strings.Join(change.columns, ","))
+			}
+			for _, req := range merge.removed {
+				removed = append(removed, req.UID)
+			}
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("added = %q, want %q", added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(changed, tt.wantChanged) {
+				t.Errorf("changed = %q, want %q", changed, tt.wantChanged)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed = %q, want %q", removed, tt.wantRemoved)
+			}
+
+			merge.apply(project)
+			if got := treeOf(project); !reflect.DeepEqual(got, tt.wantTree) {
+				t.Errorf("tree after apply = %q, want %q", got, tt.wantTree)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// metadataFlags lists the flags that set optional requirement metadata, with their help text
+var metadataFlags = []struct {
+	name  string
+	usage string
+}{
+	{"rationale", "Why the requirement exists"},
+	{"source", "Stakeholder or document the requirement comes from"},
+	{"owner", "Person or team responsible for the requirement"},
+}
+
+// addMetadataFlags registers the metadata flags on a command
+func addMetadataFlags(cmd *cobra.Command) {
+	for _, flag := range metadataFlags {
+		cmd.Flags().String(flag.name, "", flag.usage)
+	}
+}
+
+// metadataField returns the requirement field set by a metadata flag
+func metadataField(req *types.Requirement, name string) *string {
+	switch name {
+	case "rationale":
+		return &req.Rationale
+	case "source":
+		return &req.Source
+	case "owner":
+		return &req.Owner
+	}
+	return nil
+}
+
+// metadataFlagsChanged reports whether any metadata flag was given on the command line
+func metadataFlagsChanged(cmd *cobra.Command) bool {
+	for _, flag := range metadataFlags {
+		if cmd.Flags().Changed(flag.name) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyMetadataFlags copies the given metadata flags onto the requirement and reports whether anything changed
+func applyMetadataFlags(cmd *cobra.Command, req *types.Requirement) bool {
+	changed := false
+	for _, flag := range metadataFlags {
+		if !cmd.Flags().Changed(flag.name) {
+			continue
+		}
+		value, _ := cmd.Flags().GetString(flag.name)
+		if field := metadataField(req, flag.name); *field != value {
+			*field = value
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade requirements.yaml to the current file format",
+	Long: `Upgrade requirements.yaml to the current file format. Assigns a permanent UID to every
+requirement that does not have one yet.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		assigned := project.AssignUIDs()
+		if assigned == 0 {
+			fmt.Println("requirements.yaml is up to date.")
+			return
+		}
+
+		// Save project
+		if err := project.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Assigned UIDs to %d requirements\n", assigned)
+	},
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// rootParent is the --to value that moves a requirement to the top level
+const rootParent = "root"
+
+var MoveCmd = &cobra.Command{
+	Use:     "move [requirement_id]",
+	Aliases: []string{"mv"},
+	Short:   "Move a requirement subtree under a new parent",
+	Long: `Move a requirement and all of its children under a new parent, or to the top level with
+--to root. The moved subtree gets new IDs and the siblings it leaves behind are renumbered.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		requirementID := args[0]
+		newParentID, _ := cmd.Flags().GetString("to")
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		requirement := project.FindRequirement(requirementID)
+		if requirement == nil {
+			fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", requirementID)
+			os.Exit(1)
+		}
+		requirementID = requirement.ID
+
+		if newParentID == rootParent {
+			newParentID = ""
+		} else {
+			newParent := project.FindRequirement(newParentID)
+			if newParent == nil {
+				fmt.Fprintf(os.Stderr, "Error: Parent requirement '%s' not found\n", newParentID)
+				os.Exit(1)
+			}
+			newParentID = newParent.ID
+			if newParentID == requirementID || strings.HasPrefix(newParentID, requirementID+".") {
+				fmt.Fprintf(os.Stderr, "Error: Cannot move '%s' under itself or one of its descendants\n", requirementID)
+				os.Exit(1)
+			}
+		}
+
+		if parentOf(requirementID) == newParentID {
+			fmt.Printf("%s is already under %s\n", requirementID, displayParent(newParentID))
+			return
+		}
+
+		// Detach the subtree; the new parent may be renumbered along with the old siblings
+		moved, _ := project.RemoveRequirement(requirementID, true)
+		if newParentID != "" {
+			newParentID = types.IDAfterRemoval(newParentID, requirementID)
+		}
+
+		// Attach the subtree under its new parent
+		moved.SetID(nextRequirementID(newParentID, project))
+		moved.Touch()
+		if newParentID == "" {
+			project.Requirements = append(project.Requirements, moved)
+		} else if !addChildRequirement(project.Requirements, newParentID, moved) {
+			fmt.Fprintf(os.Stderr, "Error: Parent requirement '%s' not found\n", newParentID)
+			os.Exit(1)
+		}
+
+		// Save project
+		if err := project.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Moved %s to %s\n", requirementID, displayRequirement(&moved))
+	},
+}
+
+func init() {
+	MoveCmd.Flags().String("to", "", "New parent requirement ID, or 'root' for the top level")
+	MoveCmd.MarkFlagRequired("to")
+}
+
+// parentOf returns the parent ID of a dot-notation requirement ID ("" for top level)
+func parentOf(id string) string {
+	if i := strings.LastIndex(id, "."); i >= 0 {
+		return id[:i]
+	}
+	return ""
+}
+
+// displayParent returns a human-readable name for a parent ID
+func displayParent(parentID string) string {
+	if parentID == "" {
+		return rootParent
+	}
+	return parentID
+}
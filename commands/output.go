@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats selected with the global --output flag
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// outputFormats lists every supported output format
+var outputFormats = []string{outputText, outputJSON, outputYAML}
+
+// structuredOutput is the annotation of commands that honour --output json and yaml; other
+// commands reject those formats rather than silently printing text
+const structuredOutput = "structured-output"
+
+// outputFormat returns the output format selected with --output
+func outputFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("output")
+	return format
+}
+
+// validateOutputFormat checks the --output flag before any command runs
+func validateOutputFormat(cmd *cobra.Command) error {
+	format := outputFormat(cmd)
+	if !slices.Contains(outputFormats, format) {
+		return fmt.Errorf("unknown output format '%s' (supported: text, json, yaml)", format)
+	}
+	if format != outputText && cmd.Annotations[structuredOutput] == "" {
+		return fmt.Errorf("'%s' does not support --output %s", cmd.CommandPath(), format)
+	}
+	return nil
+}
+
+// progressWriter returns where progress and questions go: stdout for text output, stderr when
+// stdout is reserved for structured output
+func progressWriter(format string) io.Writer {
+	if format == outputText {
+		return os.Stdout
+	}
+	return os.Stderr
+}
+
+// printStructured writes v to stdout as JSON or YAML
+func printStructured(format string, v any) {
+	var err error
+	switch format {
+	case outputJSON:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(v)
+	case outputYAML:
+		var data []byte
+		if data, err = yaml.Marshal(v); err == nil {
+			_, err = os.Stdout.Write(data)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// nonNil returns an empty list instead of nil so that it is printed as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
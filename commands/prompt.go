@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Answer policies for questions that scripts cannot answer interactively
+const (
+	policyAlways = "always"
+	policyNever  = "never"
+	policyAsk    = "ask"
+)
+
+// prompter asks yes/no questions on stdin. It shares a single reader between questions so that
+// input buffered for one question is not lost to the next.
+type prompter struct {
+	reader      *bufio.Reader
+	interactive bool
+	out         io.Writer
+}
+
+// newPrompter returns a prompter that writes questions and progress to out
+func newPrompter(out io.Writer) *prompter {
+	return &prompter{
+		reader:      bufio.NewReader(os.Stdin),
+		interactive: stdinIsTerminal(),
+		out:         out,
+	}
+}
+
+// stdinIsTerminal reports whether stdin is an interactive terminal rather than a pipe, file or
+// /dev/null
+func stdinIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// printf writes progress output
+func (p *prompter) printf(format string, args ...any) {
+	fmt.Fprintf(p.out, format, args...)
+}
+
+// confirm asks a yes/no question that defaults to yes. The answer comes from the policy unless
+// the policy is "ask" and stdin is a terminal; without a terminal, "ask" answers no.
+func (p *prompter) confirm(question, policy string) (bool, error) {
+	p.printf("%s [Y/n]: ", question)
+
+	switch {
+	case policy == policyAlways:
+		p.printf("y (always)\n")
+		return true, nil
+	case policy == policyNever:
+		p.printf("n (never)\n")
+		return false, nil
+	case !p.interactive:
+		p.printf("n (stdin is not a terminal)\n")
+		return false, nil
+	}
+
+	response, err := p.reader.ReadString('\n')
+	if errors.Is(err, io.EOF) && strings.TrimSpace(response) == "" {
+		// Input ended without an answer, as when stdin is closed
+		p.printf("n (end of input)\n")
+		return false, nil
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read user input: %w", err)
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+
+	// Default to "yes" if empty response or "y"
+	return response == "" || response == "y" || response == "yes", nil
+}
+
+// addPolicyFlag registers an always|never|ask flag
+func addPolicyFlag(cmd *cobra.Command, name, usage string) {
+	cmd.Flags().String(name, policyAsk, usage+" (always, never or ask)")
+}
+
+// getPolicy reads a policy flag; --yes turns an unset policy into "always"
+func getPolicy(cmd *cobra.Command, name string) (string, error) {
+	policy, _ := cmd.Flags().GetString(name)
+	if yes, _ := cmd.Flags().GetBool("yes"); yes && !cmd.Flags().Changed(name) {
+		policy = policyAlways
+	}
+
+	if !slices.Contains([]string{policyAlways, policyNever, policyAsk}, policy) {
+		return "", fmt.Errorf("invalid --%s '%s' (expected always, never or ask)", name, policy)
+	}
+	return policy, nil
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestPrompter_confirm(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		interactive bool
+		policy      string
+		want        bool
+	}{
+		{name: "empty answer defaults to yes", input: "\n", interactive: true, policy: policyAsk, want: true},
+		{name: "yes", input: "yes\n", interactive: true, policy: policyAsk, want: true},
+		{name: "no", input: "n\n", interactive: true, policy: policyAsk, want: false},
+		{name: "end of input answers no", input: "", interactive: true, policy: policyAsk, want: false},
+		{name: "answer without newline", input: "y", interactive: true, policy: policyAsk, want: true},
+		{name: "not a terminal answers no", input: "y\n", interactive: false, policy: policyAsk, want: false},
+		{name: "always", input: "", interactive: false, policy: policyAlways, want: true},
+		{name: "never", input: "y\n", interactive: true, policy: policyNever, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &prompter{reader: bufio.NewReader(strings.NewReader(tt.input)), interactive: tt.interactive, out: io.Discard}
+			got, err := p.confirm("Continue?", tt.policy)
+			if err != nil {
+				t.Fatalf("confirm() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("confirm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var RemoveCmd = &cobra.Command{
+	Use:     "remove [requirement_id]",
+	Aliases: []string{"rm"},
+	Short:   "Remove a requirement",
+	Long: `Remove a requirement from the project. Requirements with children are only removed when
+--recursive or --reparent-children-to is given. The siblings that follow are renumbered to keep
+IDs gap-free unless --keep-ids is set.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		requirementID := args[0]
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		reparentTo, _ := cmd.Flags().GetString("reparent-children-to")
+		keepIDs, _ := cmd.Flags().GetBool("keep-ids")
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		requirement := project.FindRequirement(requirementID)
+		if requirement == nil {
+			fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", requirementID)
+			os.Exit(1)
+		}
+
+		requirementID = requirement.ID
+
+		if recursive && reparentTo != "" {
+			fmt.Fprintf(os.Stderr, "Error: --recursive and --reparent-children-to cannot be used together\n")
+			os.Exit(1)
+		}
+
+		if len(requirement.Children) > 0 {
+			switch {
+			case reparentTo != "":
+				if err := reparentChildren(project, requirementID, reparentTo); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+					os.Exit(1)
+				}
+			case !recursive:
+				fmt.Fprintf(os.Stderr, "Error: Requirement '%s' has %d children. Use --recursive or --reparent-children-to.\n", requirementID, len(requirement.Children))
+				os.Exit(1)
+			}
+		}
+
+		removed, _ := project.RemoveRequirement(requirementID, !keepIDs)
+
+		// Drop links that pointed into the removed subtree
+		var removedUIDs []string
+		removed.Walk(func(req *types.Requirement) {
+			if req.UID != "" {
+				removedUIDs = append(removedUIDs, req.UID)
+			}
+		})
+		droppedLinks := project.RemoveLinksTo(removedUIDs)
+
+		// Save project
+		if err := project.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Removed %s\n", displayRequirement(&removed))
+		if droppedLinks > 0 {
+			fmt.Printf("Removed %d links to the deleted requirements\n", droppedLinks)
+		}
+	},
+}
+
+func init() {
+	RemoveCmd.Flags().BoolP("recursive", "r", false, "Remove the requirement together with all of its children")
+	RemoveCmd.Flags().String("reparent-children-to", "", "Move the requirement's children under this requirement before removing it")
+	RemoveCmd.Flags().Bool("keep-ids", false, "Leave a gap instead of renumbering the following siblings")
+}
+
+// reparentChildren moves all children of the requirement to the new parent, assigning new IDs
+func reparentChildren(project *types.Project, requirementID, newParentID string) error {
+	newParent := project.FindRequirement(newParentID)
+	if newParent == nil {
+		return fmt.Errorf("parent requirement '%s' not found", newParentID)
+	}
+	newParentID = newParent.ID
+	if newParentID == requirementID || strings.HasPrefix(newParentID, requirementID+".") {
+		return fmt.Errorf("cannot reparent children to '%s': it is part of the removed subtree", newParentID)
+	}
+
+	requirement := project.FindRequirement(requirementID)
+	children := requirement.Children
+	requirement.Children = nil
+
+	for _, child := range children {
+		child.SetID(nextRequirementID(newParentID, project))
+		child.Touch()
+		addChildRequirement(project.Requirements, newParentID, child)
+	}
+
+	return nil
+}
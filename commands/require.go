@@ -1,13 +1,14 @@
 package commands
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
-	"github.com/techcorrectco/reqd/internal/openai"
+	"github.com/techcorrectco/reqd/internal/ai"
 	"github.com/techcorrectco/reqd/internal/types"
 )
 
@@ -15,13 +16,52 @@ var RequireCmd = &cobra.Command{
 	Use:     "require [requirement title]",
 	Aliases: []string{"r"},
 	Short:   "Document a new system requirement",
-	Long:    `Add a new requirement to the project. Generates an ID and adds it to the requirements hierarchy.`,
-	Args:    cobra.ExactArgs(1),
+	Long: `Add a new requirement to the project. Generates an ID and adds it to the requirements hierarchy.
+
+Before the requirement is saved, it is compared with the existing requirements using embeddings
+when the AI provider supports them. If it looks like one of them, a warning names the similar
+requirements and it is added anyway, unless --accept-duplicate is never (refuse) or ask.
+
+Questions about AI recommendations can be answered in advance with --yes, --accept-recommendation,
+--accept-parent and --accept-duplicate. When stdin is not a terminal, unanswered questions default to no.`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{structuredOutput: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
 		requirementTitle := args[0]
 		parentID, _ := cmd.Flags().GetString("parent")
 		noValidate, _ := cmd.Flags().GetBool("no-validate")
 		noParentProposal, _ := cmd.Flags().GetBool("no-parent-proposal")
+		noDuplicateCheck, _ := cmd.Flags().GetBool("no-duplicate-check")
+		priority, _ := cmd.Flags().GetString("priority")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		output := outputFormat(cmd)
+
+		if priority != "" {
+			var err error
+			if priority, err = types.ParsePriority(priority); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		acceptRecommendation, err := getPolicy(cmd, "accept-recommendation")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		acceptParent, err := getPolicy(cmd, "accept-parent")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		acceptDuplicate, err := getPolicy(cmd, "accept-duplicate")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Keep stdout machine-readable by sending progress and questions to stderr
+		p := newPrompter(progressWriter(output))
 
 		// Load existing project
 		project, err := types.LoadProject()
@@ -30,24 +70,36 @@ var RequireCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		var finalTitle string
-		// Auto-skip validation if no API key is set and --no-validate wasn't explicitly used
-		if noValidate || os.Getenv("OPENAI_API_KEY") == "" {
-			// Skip validation, use original title
-			finalTitle = requirementTitle
-		} else {
-			// Validate requirement with OpenAI
-			finalTitle, err = validateRequirement(requirementTitle)
+		// Resolve a parent given by UID to its positional ID
+		if parentID != "" {
+			parent := project.FindRequirement(parentID)
+			if parent == nil {
+				fmt.Fprintf(os.Stderr, "Error: Parent requirement '%s' not found\n", parentID)
+				os.Exit(1)
+			}
+			parentID = parent.ID
+		}
+
+		finalTitle, validation := reviewRequirement(cmd.Context(), p, requirementTitle, noValidate, acceptRecommendation)
+		warnPriorityConflict(priority, finalTitle)
+
+		var duplicates []duplicateResult
+		if !noDuplicateCheck && len(project.Requirements) > 0 && ai.EmbeddingsAvailable() {
+			var add bool
+			duplicates, add, err = checkDuplicates(cmd.Context(), p, finalTitle, project, acceptDuplicate)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
-				fmt.Fprintf(os.Stderr, "Proceeding with original requirement...\n")
-				finalTitle = requirementTitle
+				fmt.Fprintf(os.Stderr, "Warning: duplicate check failed: %v\n", err)
+			} else if !add {
+				fmt.Fprintf(os.Stderr, "Requirement not added.\n")
+				os.Exit(1)
 			}
 		}
 
 		// If no parent ID provided and parent proposal not disabled, ask if user wants a parent proposed
-		if parentID == "" && !noParentProposal && os.Getenv("OPENAI_API_KEY") != "" {
-			proposedParent, err := proposeRequirementParent(finalTitle, project)
+		var parentProposal *parentResult
+		if parentID == "" && !noParentProposal && ai.Available() {
+			var proposedParent string
+			proposedParent, parentProposal, err = proposeRequirementParent(cmd.Context(), p, finalTitle, project, acceptParent)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 			} else if proposedParent != "" {
@@ -57,6 +109,11 @@ var RequireCmd = &cobra.Command{
 
 		// Generate new requirement
 		newReq := createRequirement(finalTitle, parentID, project)
+		newReq.Priority = priority
+		applyMetadataFlags(cmd, &newReq)
+		for _, tag := range tags {
+			newReq.AddTag(tag)
+		}
 
 		// Add requirement to project
 		if parentID == "" {
@@ -76,39 +133,98 @@ var RequireCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		fmt.Printf("\n%s: %s\n", newReq.ID, newReq.Text)
+		if output != outputText {
+			printStructured(output, requireResult{
+				ID:             newReq.ID,
+				UID:            newReq.UID,
+				Parent:         parentID,
+				Input:          requirementTitle,
+				Text:           newReq.Text,
+				Validation:     validation,
+				ParentProposal: parentProposal,
+				Duplicates:     duplicates,
+			})
+			return
+		}
+
+		fmt.Printf("\n%s\n", displayRequirement(&newReq))
 	},
 }
 
+// requireResult is the machine-readable record of what `reqd require` decided
+type requireResult struct {
+	ID             string            `json:"id" yaml:"id"`
+	UID            string            `json:"uid" yaml:"uid"`
+	Parent         string            `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Input          string            `json:"input" yaml:"input"`
+	Text           string            `json:"text" yaml:"text"`
+	Validation     *reviewResult     `json:"validation,omitempty" yaml:"validation,omitempty"`
+	ParentProposal *parentResult     `json:"parent_proposal,omitempty" yaml:"parent_proposal,omitempty"`
+	Duplicates     []duplicateResult `json:"duplicates,omitempty" yaml:"duplicates,omitempty"`
+}
+
 func init() {
 	RequireCmd.Flags().StringP("parent", "p", "", "Parent requirement ID")
-	RequireCmd.Flags().BoolP("no-validate", "V", false, "Skip OpenAI validation of the requirement")
+	RequireCmd.Flags().BoolP("no-validate", "V", false, "Skip AI validation of the requirement")
 	RequireCmd.Flags().BoolP("no-parent-proposal", "P", false, "Skip proposing a parent for this requirement")
+	RequireCmd.Flags().Bool("no-duplicate-check", false, "Skip comparing the requirement with existing ones")
+	RequireCmd.Flags().String("priority", "", "MoSCoW priority: must, should, could or wont")
+	RequireCmd.Flags().StringSliceP("tag", "t", nil, "Tag for the requirement (repeatable)")
+	addMetadataFlags(RequireCmd)
+	RequireCmd.Flags().BoolP("yes", "y", false, "Accept the recommended text and suggested parent without asking")
+	addPolicyFlag(RequireCmd, "accept-recommendation", "Whether to accept the recommended text")
+	addPolicyFlag(RequireCmd, "accept-parent", "Whether to request and accept a suggested parent")
+	// Likely duplicates are only reported by default so that scripts are not blocked by them
+	RequireCmd.Flags().String("accept-duplicate", policyAlways, "Whether to add the requirement when it looks like an existing one (always, never or ask)")
+}
+
+// warnPriorityConflict warns when the priority disagrees with the RFC 2119 keyword in the text
+func warnPriorityConflict(priority, text string) {
+	if conflict := types.PriorityConflict(priority, text); conflict != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", conflict)
+	}
 }
 
 // createRequirement generates a new requirement with proper ID
 func createRequirement(title, parentID string, project *types.Project) types.Requirement {
-	var id string
+	req := types.Requirement{
+		ID:       nextRequirementID(parentID, project),
+		UID:      project.NewUID(),
+		Text:     title,
+		Children: []types.Requirement{},
+	}
+	req.Touch()
+	req.Created = req.Updated
+	return req
+}
 
+// nextRequirementID returns the ID for a new child of parentID ("" for top level)
+func nextRequirementID(parentID string, project *types.Project) string {
 	if parentID == "" {
 		// Top-level requirement: use sequence number only
-		id = fmt.Sprintf("%d", len(project.Requirements)+1)
-	} else {
-		// Child requirement: find parent and generate child ID
-		parent := project.FindRequirement(parentID)
-		if parent != nil {
-			id = fmt.Sprintf("%s.%d", parentID, len(parent.Children)+1)
-		} else {
-			// Fallback if parent not found
-			id = fmt.Sprintf("%d", len(project.Requirements)+1)
-		}
+		return types.ChildID("", nextSequence(project.Requirements))
 	}
 
-	return types.Requirement{
-		ID:       id,
-		Text:     title,
-		Children: []types.Requirement{},
+	// Child requirement: find parent and generate child ID
+	parent := project.FindRequirement(parentID)
+	if parent == nil {
+		// Fallback if parent not found
+		return types.ChildID("", nextSequence(project.Requirements))
+	}
+	return types.ChildID(parentID, nextSequence(parent.Children))
+}
+
+// nextSequence returns one past the highest sequence number among siblings,
+// so IDs are not reused when gaps were left by removals
+func nextSequence(siblings []types.Requirement) int {
+	highest := len(siblings)
+	for _, sibling := range siblings {
+		segment := sibling.ID[strings.LastIndex(sibling.ID, ".")+1:]
+		if n, err := strconv.Atoi(segment); err == nil && n > highest {
+			highest = n
+		}
 	}
+	return highest + 1
 }
 
 // addChildRequirement finds the parent and adds the child requirement
@@ -126,101 +242,192 @@ func addChildRequirement(requirements []types.Requirement, parentID string, chil
 	return false
 }
 
-// validateRequirement validates a requirement using OpenAI and returns the final title to use
-func validateRequirement(input string) (string, error) {
-	fmt.Println("Reviewing...")
+// reviewResult records the outcome of validating a requirement
+type reviewResult struct {
+	Problems    []string `json:"problems,omitempty" yaml:"problems,omitempty"`
+	Recommended string   `json:"recommended,omitempty" yaml:"recommended,omitempty"`
+	Accepted    bool     `json:"accepted" yaml:"accepted"`
+	Error       string   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// parentResult records the outcome of proposing a parent for a requirement
+type parentResult struct {
+	Proposed string `json:"proposed,omitempty" yaml:"proposed,omitempty"`
+	Accepted bool   `json:"accepted" yaml:"accepted"`
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// duplicateResult records an existing requirement that a new one looks like
+type duplicateResult struct {
+	ID         string  `json:"id" yaml:"id"`
+	UID        string  `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Text       string  `json:"text" yaml:"text"`
+	Similarity float64 `json:"similarity" yaml:"similarity"`
+}
+
+// maxDuplicates is the number of likely duplicates reported for a new requirement
+const maxDuplicates = 3
+
+// checkDuplicates compares text with the existing requirements and warns about those at least as
+// similar as the duplicate threshold. The requirement is added unless the policy refuses it, or
+// asks and the answer is no.
+func checkDuplicates(ctx context.Context, p *prompter, text string, project *types.Project, policy string) ([]duplicateResult, bool, error) {
+	threshold, err := ai.DuplicateThreshold()
+	if err != nil {
+		return nil, true, err
+	}
+
+	var requirements []*types.Requirement
+	project.Walk(func(req *types.Requirement) {
+		requirements = append(requirements, req)
+	})
+
+	ctx, stop := withInterrupt(ctx)
+	ranked, err := ai.RankBySimilarity(ctx, text, requirements)
+	stop()
+	if err != nil {
+		exitIfCancelled(err)
+		return nil, true, err
+	}
+
+	var duplicates []duplicateResult
+	for _, similar := range ranked {
+		if similar.Score < threshold || len(duplicates) == maxDuplicates {
+			break
+		}
+		req := similar.Requirement
+		fmt.Fprintf(os.Stderr, "Warning: This looks like %s (similarity %.2f)\n", displayRequirement(req), similar.Score)
+		duplicates = append(duplicates, duplicateResult{ID: req.ID, UID: req.UID, Text: req.Text, Similarity: similar.Score})
+	}
+	if len(duplicates) == 0 {
+		return nil, true, nil
+	}
+
+	if policy == policyAlways {
+		return duplicates, true, nil
+	}
+	add, err := p.confirm("Add it anyway?", policy)
+	return duplicates, add, err
+}
+
+// reviewRequirement runs the validation flow unless it is disabled or no AI provider is configured,
+// falling back to the original text when validation fails. The result is nil when validation was skipped.
+func reviewRequirement(ctx context.Context, p *prompter, text string, noValidate bool, policy string) (string, *reviewResult) {
+	// Auto-skip validation if no AI provider is configured and --no-validate wasn't explicitly used
+	if noValidate || !ai.Available() {
+		return text, nil
+	}
+
+	// Validate requirement with the AI provider
+	finalText, result, err := validateRequirement(ctx, p, text, policy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Proceeding with original requirement...\n")
+		if result == nil {
+			result = &reviewResult{}
+		}
+		result.Error = err.Error()
+		return text, result
+	}
+
+	return finalText, result
+}
+
+// validateRequirement validates a requirement using the AI provider and returns the final title to use
+func validateRequirement(ctx context.Context, p *prompter, input string, policy string) (string, *reviewResult, error) {
+	p.printf("Reviewing...\n")
 
-	validation, err := openai.ValidateRequirement(input)
+	ctx, stop := withInterrupt(ctx)
+	validation, err := ai.ValidateRequirement(ctx, input)
+	stop()
 	if err != nil {
-		return "", err
+		exitIfCancelled(err)
+		return "", nil, err
+	}
+
+	result := &reviewResult{
+		Problems:    validation.Problems,
+		Recommended: validation.Recommended,
 	}
 
 	// Display validation results
-	fmt.Printf("\nInput:\n%s\n\n", input)
+	p.printf("\nInput:\n%s\n\n", input)
 
 	if len(validation.Problems) > 0 {
-		fmt.Println("Issues:")
+		p.printf("Issues:\n")
 		for _, problem := range validation.Problems {
-			fmt.Printf("- %s\n", problem)
+			p.printf("- %s\n", problem)
 		}
-		fmt.Println()
+		p.printf("\n")
 	}
 
-	fmt.Printf("Recommended:\n%s\n\n", validation.Recommended)
+	p.printf("Recommended:\n%s\n\n", validation.Recommended)
 
 	// Ask user if they want to accept recommended changes
-	fmt.Print("Accept recommended changes? [Y/n]: ")
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+	accepted, err := p.confirm("Accept recommended changes?", policy)
 	if err != nil {
-		return "", fmt.Errorf("failed to read user input: %w", err)
+		return "", result, err
 	}
 
-	response = strings.TrimSpace(strings.ToLower(response))
-
-	// Default to "yes" if empty response or "y"
-	if response == "" || response == "y" || response == "yes" {
-		return validation.Recommended, nil
+	if accepted {
+		result.Accepted = true
+		return validation.Recommended, result, nil
 	}
 
-	return input, nil
+	return input, result, nil
 }
 
 // proposeRequirementParent asks user if they want a parent proposed and handles the proposal
-func proposeRequirementParent(requirement string, project *types.Project) (string, error) {
+func proposeRequirementParent(ctx context.Context, p *prompter, requirement string, project *types.Project, policy string) (string, *parentResult, error) {
 	// Ask user if they want a parent proposed (default to yes)
-	fmt.Print("Would you like a parent proposed for this requirement? [Y/n]: ")
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return "", fmt.Errorf("failed to read user input: %w", err)
+	wanted, err := p.confirm("Would you like a parent proposed for this requirement?", policy)
+	if err != nil || !wanted {
+		return "", nil, err
 	}
 
-	response = strings.TrimSpace(strings.ToLower(response))
+	// Auto-skip parent proposal if no AI provider is configured
+	if !ai.Available() {
+		p.printf("Skipping parent proposal (no AI provider configured)\n")
+		return "", nil, nil
+	}
 
-	// Default to "yes" if empty response or "y"
-	if response != "n" && response != "no" {
-		// Auto-skip parent proposal if no API key is set
-		if os.Getenv("OPENAI_API_KEY") == "" {
-			fmt.Println("Skipping parent proposal (no OPENAI_API_KEY set)")
-			return "", nil
-		}
+	// Get all branch requirements
+	branches := project.GetBranches()
+	if len(branches) == 0 {
+		p.printf("No existing requirements with children found to use as parents.\n")
+		return "", nil, nil
+	}
 
-		// Get all branch requirements
-		branches := project.GetBranches()
-		if len(branches) == 0 {
-			fmt.Println("No existing requirements with children found to use as parents.")
-			return "", nil
-		}
+	// Get parent proposal from the AI provider
+	ctx, stop := withInterrupt(ctx)
+	proposal, err := ai.ProposeParent(ctx, requirement, branches)
+	stop()
+	if err != nil {
+		exitIfCancelled(err)
+		err = fmt.Errorf("failed to get parent proposal: %w", err)
+		return "", &parentResult{Error: err.Error()}, err
+	}
 
-		// Get parent proposal from OpenAI
-		proposal, err := openai.ProposeParent(requirement, branches)
-		if err != nil {
-			return "", fmt.Errorf("failed to get parent proposal: %w", err)
-		}
-
-		if proposal.ProposedParent != nil && *proposal.ProposedParent != "" {
-			proposedParent := project.FindRequirement(*proposal.ProposedParent)
-			if proposedParent != nil {
-				fmt.Printf("\nSuggested parent: %s\n", proposedParent.DisplayFormat())
-				fmt.Print("Accept suggested parent? [Y/n]: ")
-				
-				acceptResponse, err := reader.ReadString('\n')
-				if err != nil {
-					return "", fmt.Errorf("failed to read user input: %w", err)
-				}
-
-				acceptResponse = strings.TrimSpace(strings.ToLower(acceptResponse))
-				
-				// Default to "yes" if empty response or "y"
-				if acceptResponse == "" || acceptResponse == "y" || acceptResponse == "yes" {
-					return *proposal.ProposedParent, nil
-				}
+	result := &parentResult{}
+	if proposal.ProposedParent != nil && *proposal.ProposedParent != "" {
+		proposedParent := project.FindRequirement(*proposal.ProposedParent)
+		if proposedParent != nil {
+			result.Proposed = proposedParent.ID
+			p.printf("\nSuggested parent: %s\n", proposedParent.DisplayFormat())
+
+			accepted, err := p.confirm("Accept suggested parent?", policy)
+			if err != nil {
+				return "", result, err
+			}
+
+			if accepted {
+				result.Accepted = true
+				return proposedParent.ID, result, nil
 			}
-		} else {
-			fmt.Println("No suitable parent found.")
 		}
+	} else {
+		p.printf("No suitable parent found.\n")
 	}
 
-	return "", nil
+	return "", result, nil
 }
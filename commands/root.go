@@ -1,7 +1,11 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/ai"
 )
 
 var RootCmd = &cobra.Command{
@@ -10,10 +14,36 @@ var RootCmd = &cobra.Command{
 	Long: `reqd is a CLI tool designed to help you manage the complexity 
 of your Product Requirements Document (PRD). It provides commands 
 to create, organize, and maintain your requirements effectively.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		ai.CacheEnabled = !noCache
+
+		if err := validateOutputFormat(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
 }
 
 func init() {
+	RootCmd.PersistentFlags().Bool("no-cache", false, "Do not use or update the AI response cache")
+	RootCmd.PersistentFlags().StringP("output", "o", outputText, "Output format: text, json or yaml (json and yaml with show, search, require, status, accept, tag, analyze conflicts and cache stats)")
+
 	RootCmd.AddCommand(InitCmd)
 	RootCmd.AddCommand(RequireCmd)
+	RootCmd.AddCommand(ImportCmd)
 	RootCmd.AddCommand(ShowCmd)
+	RootCmd.AddCommand(SearchCmd)
+	RootCmd.AddCommand(ExportCmd)
+	RootCmd.AddCommand(EditCmd)
+	RootCmd.AddCommand(RemoveCmd)
+	RootCmd.AddCommand(MoveCmd)
+	RootCmd.AddCommand(MigrateCmd)
+	RootCmd.AddCommand(StatusCmd)
+	RootCmd.AddCommand(AcceptCmd)
+	RootCmd.AddCommand(TagCmd)
+	RootCmd.AddCommand(LinkCmd)
+	RootCmd.AddCommand(UnlinkCmd)
+	RootCmd.AddCommand(AnalyzeCmd)
+	RootCmd.AddCommand(CacheCmd)
 }
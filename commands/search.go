@@ -0,0 +1,280 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/ai"
+	"github.com/techcorrectco/reqd/internal/search"
+	"github.com/techcorrectco/reqd/internal/types"
+	"golang.org/x/term"
+)
+
+// pathTextLength is the number of characters of each ancestor's text shown in a match's path
+const pathTextLength = 40
+
+// Exit statuses of `reqd search`, which follow grep so that scripts can tell an empty result
+// from a failure
+const (
+	searchNoMatchStatus = 1
+	searchErrorStatus   = 2
+)
+
+// ANSI escape sequences that highlight matched text on a terminal
+const (
+	highlightStart = "\x1b[1;33m"
+	highlightEnd   = "\x1b[0m"
+)
+
+var SearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Find requirements by text, tag, status or meaning",
+	Long: `Find requirements matching every term of a query and print them with their ancestor path.
+
+Terms are case-insensitive substrings of the text, acceptance criteria or rationale. Terms can be
+scoped to a field with text:, tag: or status:, and double quotes keep words together, as in
+'text:"log in" tag:security'. Tags and statuses match whole values. With --regex, terms are
+regular expressions instead.
+
+With --semantic, the query is compared by meaning with the text of every requirement using
+embeddings from the AI provider, and the most similar requirements are listed first.
+
+Exits with status 1 when nothing matches and 2 on errors, such as an invalid query.`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{structuredOutput: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		regex, _ := cmd.Flags().GetBool("regex")
+		semantic, _ := cmd.Flags().GetBool("semantic")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		if regex && semantic {
+			fmt.Fprintf(os.Stderr, "Error: --regex and --semantic cannot be used together\n")
+			os.Exit(searchErrorStatus)
+		}
+		if limit < 1 {
+			fmt.Fprintf(os.Stderr, "Error: --limit must be at least 1\n")
+			os.Exit(searchErrorStatus)
+		}
+
+		var query *search.Query
+		if !semantic {
+			var err error
+			if query, err = search.Parse(strings.Join(args, " "), regex); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(searchErrorStatus)
+			}
+		}
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(searchErrorStatus)
+		}
+
+		var matches []searchMatch
+		if semantic {
+			matches, err = semanticSearch(cmd.Context(), project, strings.Join(args, " "), limit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(searchErrorStatus)
+			}
+		} else {
+			matches = searchRequirements(project.Requirements, query.Matches, nil)
+		}
+
+		output := outputFormat(cmd)
+		if output != outputText {
+			results := []searchResult{}
+			for _, match := range matches {
+				results = append(results, searchResultFor(match))
+			}
+			printStructured(output, results)
+		} else {
+			printSearchMatches(matches, query, stdoutIsTerminal() && os.Getenv("NO_COLOR") == "")
+		}
+
+		if len(matches) == 0 {
+			if output == outputText {
+				fmt.Fprintln(os.Stderr, "No requirements match.")
+			}
+			os.Exit(searchNoMatchStatus)
+		}
+	},
+}
+
+func init() {
+	SearchCmd.Flags().BoolP("regex", "r", false, "Treat search terms as regular expressions")
+	SearchCmd.Flags().BoolP("semantic", "s", false, "Rank requirements by similarity in meaning using embeddings")
+	SearchCmd.Flags().Int("limit", 10, "Number of requirements listed by --semantic")
+}
+
+// searchMatch is a matching requirement together with its ancestors, outermost first
+type searchMatch struct {
+	requirement *types.Requirement
+	ancestors   []*types.Requirement
+	// score is the similarity to the query of a semantic search
+	score float64
+}
+
+// searchRequirements returns the requirements for which matches is true in tree order
+func searchRequirements(requirements []types.Requirement, matches func(req *types.Requirement) bool, ancestors []*types.Requirement) []searchMatch {
+	var found []searchMatch
+	for i := range requirements {
+		req := &requirements[i]
+		if matches(req) {
+			found = append(found, searchMatch{requirement: req, ancestors: ancestors})
+		}
+		found = append(found, searchRequirements(req.Children, matches, append(ancestors[:len(ancestors):len(ancestors)], req))...)
+	}
+	return found
+}
+
+// semanticSearch returns up to limit requirements ranked by the similarity of their text to the query
+func semanticSearch(ctx context.Context, project *types.Project, query string, limit int) ([]searchMatch, error) {
+	all := searchRequirements(project.Requirements, func(*types.Requirement) bool { return true }, nil)
+	requirements := make([]*types.Requirement, len(all))
+	ancestors := make(map[*types.Requirement][]*types.Requirement)
+	for i, match := range all {
+		requirements[i] = match.requirement
+		ancestors[match.requirement] = match.ancestors
+	}
+
+	ctx, stop := withInterrupt(ctx)
+	ranked, err := ai.RankBySimilarity(ctx, query, requirements)
+	stop()
+	if err != nil {
+		exitIfCancelled(err)
+		return nil, err
+	}
+
+	var matches []searchMatch
+	for _, similar := range ranked[:min(limit, len(ranked))] {
+		matches = append(matches, searchMatch{
+			requirement: similar.Requirement,
+			ancestors:   ancestors[similar.Requirement],
+			score:       similar.Score,
+		})
+	}
+	return matches, nil
+}
+
+// printSearchMatches prints each match with its ancestor path and the criteria and rationale that
+// matched, highlighting matched text when color is set. The query is nil for a semantic search,
+// whose matches are printed with their similarity instead.
+func printSearchMatches(matches []searchMatch, query *search.Query, color bool) {
+	highlights := func(field, text string) [][2]int {
+		if query == nil {
+			return nil
+		}
+		return query.Highlights(field, text)
+	}
+	highlight := func(field, text string) string {
+		if !color {
+			return text
+		}
+		return highlightRanges(text, highlights(field, text))
+	}
+
+	for i, match := range matches {
+		req := match.requirement
+		if i > 0 {
+			fmt.Println()
+		}
+
+		// Highlight a copy so that the requirement keeps its text
+		display := *req
+		display.Text = highlight(search.FieldText, req.Text)
+		fmt.Println(displayRequirement(&display))
+		if len(match.ancestors) > 0 {
+			fmt.Printf("  in: %s\n", ancestorPath(match.ancestors))
+		}
+		if query == nil {
+			fmt.Printf("  similarity: %.2f\n", match.score)
+		}
+		for _, criterion := range req.Acceptance {
+			if len(highlights("", criterion)) > 0 {
+				fmt.Printf("  - %s\n", highlight("", criterion))
+			}
+		}
+		if len(highlights("", req.Rationale)) > 0 {
+			fmt.Printf("  rationale: %s\n", highlight("", req.Rationale))
+		}
+	}
+}
+
+// highlightRanges wraps the given byte ranges of text in highlighting escape sequences
+func highlightRanges(text string, ranges [][2]int) string {
+	var b strings.Builder
+	last := 0
+	for _, r := range ranges {
+		b.WriteString(text[last:r[0]])
+		b.WriteString(highlightStart + text[r[0]:r[1]] + highlightEnd)
+		last = r[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// ancestorPath formats ancestors as "1 <text> › 1.2 <text>", shortening long texts
+func ancestorPath(ancestors []*types.Requirement) string {
+	parts := make([]string, len(ancestors))
+	for i, ancestor := range ancestors {
+		parts[i] = ancestor.ID + " " + shorten(ancestor.Text, pathTextLength)
+	}
+	return strings.Join(parts, " › ")
+}
+
+// shorten truncates text to at most length characters, marking the cut with an ellipsis
+func shorten(text string, length int) string {
+	if utf8.RuneCountInString(text) <= length {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimSpace(string(runes[:length-1])) + "…"
+}
+
+// stdoutIsTerminal reports whether stdout is an interactive terminal rather than a pipe or file
+func stdoutIsTerminal() bool {
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// searchResult is the structured output of one `reqd search` match
+type searchResult struct {
+	ID     string           `json:"id" yaml:"id"`
+	UID    string           `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Text   string           `json:"text" yaml:"text"`
+	Status string           `json:"status" yaml:"status"`
+	Tags   []string         `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Path   []searchAncestor `json:"path" yaml:"path"`
+	Score  float64          `json:"score,omitempty" yaml:"score,omitempty"`
+}
+
+// searchAncestor is an ancestor of a matching requirement
+type searchAncestor struct {
+	ID   string `json:"id" yaml:"id"`
+	UID  string `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Text string `json:"text" yaml:"text"`
+}
+
+// searchResultFor prepares the structured output of a match
+func searchResultFor(match searchMatch) searchResult {
+	req := match.requirement
+	result := searchResult{
+		ID:     req.ID,
+		UID:    req.UID,
+		Text:   req.Text,
+		Status: req.CurrentStatus(),
+		Tags:   req.Tags,
+		Path:   []searchAncestor{},
+		Score:  match.score,
+	}
+	for _, ancestor := range match.ancestors {
+		result.Path = append(result.Path, searchAncestor{ID: ancestor.ID, UID: ancestor.UID, Text: ancestor.Text})
+	}
+	return result
+}
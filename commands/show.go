@@ -3,18 +3,42 @@ package commands
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 	"github.com/techcorrectco/reqd/internal/types"
+	"golang.org/x/term"
 )
 
+// defaultTerminalWidth is the wrapping width when neither the terminal nor $COLUMNS gives one
+const defaultTerminalWidth = 80
+
 var ShowCmd = &cobra.Command{
 	Use:     "show [requirement_id]",
 	Aliases: []string{"s"},
 	Short:   "Display requirements",
-	Long:    `Display project requirements or a specific requirement with its children.`,
-	Args:    cobra.MaximumNArgs(1),
+	Long: `Display project requirements or a specific requirement with its children, as a flat list or,
+with --tree, as an indented tree. --depth limits the number of levels shown and --leaves-only and
+--branches-only restrict the list to requirements without or with children.
+
+With --output json or yaml, the selected requirements are printed as a tree with all of their
+fields, including metadata, acceptance criteria and links.`,
+	Args:        cobra.MaximumNArgs(1),
+	Annotations: map[string]string{structuredOutput: "true"},
 	Run: func(cmd *cobra.Command, args []string) {
+		statuses, _ := cmd.Flags().GetStringSlice("status")
+		priorities, _ := cmd.Flags().GetStringSlice("priority")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		tree, _ := cmd.Flags().GetBool("tree")
+		depth, _ := cmd.Flags().GetInt("depth")
+		leavesOnly, _ := cmd.Flags().GetBool("leaves-only")
+		branchesOnly, _ := cmd.Flags().GetBool("branches-only")
+		wrap, _ := cmd.Flags().GetBool("wrap")
+
 		// Load existing project
 		project, err := types.LoadProject()
 		if err != nil {
@@ -22,6 +46,56 @@ var ShowCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		for i, status := range statuses {
+			statuses[i] = strings.ToLower(status)
+			if !slices.Contains(project.Statuses(), statuses[i]) {
+				fmt.Fprintf(os.Stderr, "Error: Unknown status '%s'\n", status)
+				os.Exit(1)
+			}
+		}
+
+		for i, priority := range priorities {
+			if priorities[i], err = types.ParsePriority(priority); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if sortBy != "" && sortBy != "priority" {
+			fmt.Fprintf(os.Stderr, "Error: Unknown sort order '%s' (supported: priority)\n", sortBy)
+			os.Exit(1)
+		}
+
+		if leavesOnly && branchesOnly {
+			fmt.Fprintf(os.Stderr, "Error: --leaves-only and --branches-only cannot be used together\n")
+			os.Exit(1)
+		}
+		if depth < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --depth must not be negative\n")
+			os.Exit(1)
+		}
+
+		filter := showFilter{
+			statuses:       statuses,
+			priorities:     priorities,
+			tags:           tags,
+			sortByPriority: sortBy == "priority",
+			depth:          depth,
+		}
+		switch {
+		case leavesOnly:
+			filter.kind = showLeaves
+		case branchesOnly:
+			filter.kind = showBranches
+		}
+
+		view := showView{filter: filter, tree: tree}
+		if wrap {
+			view.width = terminalWidth()
+		}
+
+		output := outputFormat(cmd)
+
 		if len(args) > 0 {
 			// Show specific requirement and its children
 			requirementID := args[0]
@@ -30,26 +104,345 @@ var ShowCmd = &cobra.Command{
 				fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", requirementID)
 				os.Exit(1)
 			}
-			showRequirement(requirement)
+			if output != outputText {
+				printStructured(output, requirementOutputFor(project, requirement, filter))
+				return
+			}
+			view.show([]types.Requirement{*requirement}, 1)
+			showLinks(project, requirement)
 		} else {
 			// Show entire list of requirements
-			showRequirements(project.Requirements)
+			if output != outputText {
+				printStructured(output, showOutput{
+					Name:         project.Name,
+					Requirements: filterRequirements(project.Requirements, filter, 1),
+				})
+				return
+			}
+			view.show(project.Requirements, 1)
 		}
 	},
 }
 
-// showRequirements renders a list of requirements
-func showRequirements(requirements []types.Requirement) {
-	for _, req := range requirements {
-		showRequirement(&req)
+func init() {
+	ShowCmd.Flags().StringSlice("status", nil, "Only show requirements with this status (repeatable)")
+	ShowCmd.Flags().StringSlice("priority", nil, "Only show requirements with this priority (repeatable)")
+	ShowCmd.Flags().String("sort", "", "Sort siblings by the given field (priority)")
+	ShowCmd.Flags().StringSlice("tag", nil, "Only show requirements with this tag, or without it when prefixed with '!' (repeatable)")
+	ShowCmd.Flags().Bool("tree", false, "Draw the hierarchy as an indented tree")
+	ShowCmd.Flags().Int("depth", 0, "Only show this many levels (0 for all)")
+	ShowCmd.Flags().Bool("leaves-only", false, "Only show requirements without children")
+	ShowCmd.Flags().Bool("branches-only", false, "Only show requirements with children")
+	ShowCmd.Flags().Bool("wrap", false, "Wrap long lines to the terminal width (or $COLUMNS, default 80)")
+}
+
+// showFilter selects which requirements are displayed and in which order
+type showFilter struct {
+	statuses       []string
+	priorities     []string
+	tags           []string
+	sortByPriority bool
+	// kind is showLeaves or showBranches to list only that kind of requirement
+	kind string
+	// depth is the number of levels to display, 0 for all
+	depth int
+}
+
+// Requirement kinds selected with --leaves-only and --branches-only
+const (
+	showLeaves   = "leaves"
+	showBranches = "branches"
+)
+
+// matches reports whether a requirement passes the filter
+func (f showFilter) matches(req *types.Requirement) bool {
+	if len(f.statuses) > 0 && !slices.Contains(f.statuses, req.CurrentStatus()) {
+		return false
+	}
+	if len(f.priorities) > 0 && !slices.Contains(f.priorities, req.Priority) {
+		return false
+	}
+	switch f.kind {
+	case showLeaves:
+		if len(req.Children) > 0 {
+			return false
+		}
+	case showBranches:
+		if len(req.Children) == 0 {
+			return false
+		}
+	}
+	return req.MatchesTags(f.tags)
+}
+
+// matchesSubtree reports whether the requirement at the given level (1 for the first level
+// displayed) or any of its descendants within the depth limit passes the filter
+func (f showFilter) matchesSubtree(req *types.Requirement, level int) bool {
+	if f.beyondDepth(level) {
+		return false
+	}
+	if f.matches(req) {
+		return true
+	}
+	for i := range req.Children {
+		if f.matchesSubtree(&req.Children[i], level+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// beyondDepth reports whether a level is cut off by the depth limit
+func (f showFilter) beyondDepth(level int) bool {
+	return f.depth > 0 && level > f.depth
+}
+
+// order returns the requirements in display order
+func (f showFilter) order(requirements []types.Requirement) []types.Requirement {
+	if !f.sortByPriority {
+		return requirements
+	}
+	sorted := slices.Clone(requirements)
+	slices.SortStableFunc(sorted, func(a, b types.Requirement) int {
+		return types.PriorityRank(a.Priority) - types.PriorityRank(b.Priority)
+	})
+	return sorted
+}
+
+// showView renders requirements as a flat list or, with tree set, as a box-drawn tree.
+// A positive width wraps long lines.
+type showView struct {
+	filter showFilter
+	tree   bool
+	width  int
+}
+
+// Box-drawing segments of the tree view
+const (
+	treeBranch   = "├── "
+	treeLast     = "└── "
+	treeVertical = "│   "
+	treeSpace    = "    "
+)
+
+// show renders a list of requirements at the given level (1 for the first level displayed)
+func (v showView) show(requirements []types.Requirement, level int) {
+	if v.tree {
+		v.showTree(requirements, level, "")
+	} else {
+		v.showFlat(requirements, level)
+	}
+}
+
+// showFlat renders requirements as a list in which the hierarchy shows only in the IDs.
+// Ancestors of matching requirements are rendered as context, except when listing only leaves
+// or branches.
+func (v showView) showFlat(requirements []types.Requirement, level int) {
+	for _, req := range v.filter.order(requirements) {
+		if !v.filter.matchesSubtree(&req, level) {
+			continue
+		}
+
+		matches := v.filter.matches(&req)
+		if matches || v.filter.kind == "" {
+			v.printWrapped("", "  ", displayRequirement(&req))
+		}
+		if matches {
+			for _, criterion := range req.Acceptance {
+				v.printWrapped("    - ", "      ", criterion)
+			}
+		}
+
+		v.showFlat(req.Children, level+1)
+	}
+}
+
+// showTree renders requirements as a tree below prefix. Ancestors of matching requirements are
+// always rendered to keep the structure.
+func (v showView) showTree(requirements []types.Requirement, level int, prefix string) {
+	var visible []types.Requirement
+	for _, req := range v.filter.order(requirements) {
+		if v.filter.matchesSubtree(&req, level) {
+			visible = append(visible, req)
+		}
+	}
+
+	for i, req := range visible {
+		// Top-level requirements are not connected to anything
+		connector, childPrefix := "", ""
+		if level > 1 {
+			connector, childPrefix = treeBranch, prefix+treeVertical
+			if i == len(visible)-1 {
+				connector, childPrefix = treeLast, prefix+treeSpace
+			}
+		}
+
+		// Lines below the requirement continue the vertical line to its children
+		hasChildren := slices.ContainsFunc(req.Children, func(child types.Requirement) bool {
+			return v.filter.matchesSubtree(&child, level+1)
+		})
+		detailPrefix := childPrefix + treeSpace
+		if hasChildren {
+			detailPrefix = childPrefix + treeVertical
+		}
+
+		v.printWrapped(prefix+connector, detailPrefix, displayRequirement(&req))
+		if v.filter.matches(&req) {
+			for _, criterion := range req.Acceptance {
+				v.printWrapped(detailPrefix+"- ", detailPrefix+"  ", criterion)
+			}
+		}
+
+		v.showTree(req.Children, level+1, childPrefix)
+	}
+}
+
+// printWrapped prints text after first, wrapping it to the view's width with the following
+// lines starting with rest
+func (v showView) printWrapped(first, rest, text string) {
+	if v.width <= 0 {
+		fmt.Println(first + text)
+		return
+	}
+
+	indent := max(utf8.RuneCountInString(first), utf8.RuneCountInString(rest))
+	for i, line := range wrapText(text, v.width-indent) {
+		if i == 0 {
+			fmt.Println(first + line)
+		} else {
+			fmt.Println(rest + line)
+		}
 	}
 }
 
-// showRequirement renders a single requirement and its children
-func showRequirement(req *types.Requirement) {
-	fmt.Printf("%s: %s\n", req.ID, req.Text)
+// wrapText breaks text into lines of at most width characters at spaces. Words longer than
+// width get a line of their own.
+func wrapText(text string, width int) []string {
+	// Leave room for at least a short word on narrow terminals
+	width = max(width, 20)
+
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	return append(lines, line)
+}
+
+// terminalWidth returns the width to wrap output to: the width of the terminal on stdout, or
+// $COLUMNS when stdout is not a terminal, otherwise 80
+func terminalWidth() int {
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return defaultTerminalWidth
+}
+
+// showAcceptance renders a requirement's acceptance criteria as an indented list
+func showAcceptance(req *types.Requirement) {
+	for _, criterion := range req.Acceptance {
+		fmt.Printf("    - %s\n", criterion)
+	}
+}
+
+// showLinks renders the outbound and inbound links of a requirement
+func showLinks(project *types.Project, req *types.Requirement) {
+	inbound := project.InboundLinks(req.UID)
+	if len(req.Links) == 0 && (req.UID == "" || len(inbound) == 0) {
+		return
+	}
+
+	fmt.Println("\nLinks:")
+	for _, link := range req.Links {
+		if target := project.FindRequirement(link.Target); target != nil {
+			fmt.Printf("  -> %s %s\n", link.Type, displayRequirement(target))
+		} else {
+			fmt.Printf("  -> %s %s (missing)\n", link.Type, link.Target)
+		}
+	}
+	if req.UID == "" {
+		return
+	}
+	for _, link := range inbound {
+		fmt.Printf("  <- %s %s\n", link.Type, displayRequirement(link.Source))
+	}
+}
+
+// showOutput is the structured output of `reqd show`
+type showOutput struct {
+	Name         string              `json:"name" yaml:"name"`
+	Requirements []types.Requirement `json:"requirements" yaml:"requirements"`
+}
+
+// requirementOutput is the structured output of `reqd show <id>`: the requirement's subtree
+// together with the links pointing at it
+type requirementOutput struct {
+	types.Requirement `yaml:",inline"`
+	InboundLinks      []inboundLinkOutput `json:"inbound_links,omitempty" yaml:"inbound_links,omitempty"`
+}
+
+// inboundLinkOutput is a link pointing at a requirement
+type inboundLinkOutput struct {
+	Type     string `json:"type" yaml:"type"`
+	Source   string `json:"source" yaml:"source"`
+	SourceID string `json:"source_id" yaml:"source_id"`
+}
+
+// requirementOutputFor prepares the structured output of a single requirement. It is nil when
+// the filter excludes the whole subtree.
+func requirementOutputFor(project *types.Project, req *types.Requirement, filter showFilter) *requirementOutput {
+	filtered := filterRequirements([]types.Requirement{*req}, filter, 1)
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	output := &requirementOutput{Requirement: filtered[0]}
+	if req.UID != "" {
+		for _, link := range project.InboundLinks(req.UID) {
+			output.InboundLinks = append(output.InboundLinks, inboundLinkOutput{
+				Type:     link.Type,
+				Source:   link.Source.UID,
+				SourceID: link.Source.ID,
+			})
+		}
+	}
+	return output
+}
+
+// filterRequirements returns copies of the requirements that pass the filter in display order,
+// keeping ancestors of matches as context like the tree view does. The status of each copy
+// is filled in, so that requirements without one are reported as draft.
+func filterRequirements(requirements []types.Requirement, filter showFilter, level int) []types.Requirement {
+	result := []types.Requirement{}
+	for _, req := range filter.order(requirements) {
+		if !filter.matchesSubtree(&req, level) {
+			continue
+		}
+		req.Status = req.CurrentStatus()
+		req.Children = filterRequirements(req.Children, filter, level+1)
+		if len(req.Children) == 0 {
+			req.Children = nil
+		}
+		result = append(result, req)
+	}
+	return result
+}
 
-	if len(req.Children) > 0 {
-		showRequirements(req.Children)
+// displayRequirement formats a requirement as "<id> [<uid>]: <text>", omitting a missing UID
+func displayRequirement(req *types.Requirement) string {
+	if req.UID == "" {
+		return req.DisplayFormat()
 	}
+	return fmt.Sprintf("%s [%s]: %s", req.ID, req.UID, req.Text)
 }
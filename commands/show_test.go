@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_wrapText(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  []string
+	}{
+		{
+			name:  "fits on one line",
+			text:  "The system MUST log in users",
+			width: 40,
+			want:  []string{"The system MUST log in users"},
+		},
+		{
+			name:  "breaks at spaces",
+			text:  "The system MUST lock an account after five failed logins",
+			width: 25,
+			want:  []string{"The system MUST lock an", "account after five failed", "logins"},
+		},
+		{
+			name:  "collapses whitespace",
+			text:  "The  system\tMUST\nlog in users",
+			width: 40,
+			want:  []string{"The system MUST log in users"},
+		},
+		{
+			name:  "long word gets its own line",
+			text:  "See https://example.com/requirements/authentication/login for details",
+			width: 30,
+			want:  []string{"See", "https://example.com/requirements/authentication/login", "for details"},
+		},
+		{
+			name:  "counts characters rather than bytes",
+			text:  "Die Anwendung MUSS Änderungen übernehmen",
+			width: 21,
+			want:  []string{"Die Anwendung MUSS", "Änderungen übernehmen"},
+		},
+		{
+			name:  "narrow width is raised to 20",
+			text:  "The system MUST log in users",
+			width: 5,
+			want:  []string{"The system MUST log", "in users"},
+		},
+		{
+			name:  "empty text",
+			text:  "",
+			width: 40,
+			want:  []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.text, tt.width); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var StatusCmd = &cobra.Command{
+	Use:   "status [requirement_id] [new_status]",
+	Short: "Show or change the lifecycle status of a requirement",
+	Long: `Show or change the lifecycle status of a requirement. Status changes must follow the
+project's transition graph (the 'transitions' section of requirements.yaml, or the default
+draft -> proposed -> approved -> implemented -> verified lifecycle).`,
+	Args:        cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{structuredOutput: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		requirementID := args[0]
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		requirement := project.FindRequirement(requirementID)
+		if requirement == nil {
+			fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", requirementID)
+			os.Exit(1)
+		}
+
+		current := requirement.CurrentStatus()
+
+		if len(args) == 1 {
+			if output := outputFormat(cmd); output != outputText {
+				printStructured(output, statusOutputFor(project, requirement))
+				return
+			}
+			fmt.Printf("%s: %s\n", requirement.ID, current)
+			if next := project.StatusTransitions()[current]; len(next) > 0 {
+				fmt.Printf("Allowed transitions: %s\n", strings.Join(next, ", "))
+			}
+			return
+		}
+
+		newStatus := strings.ToLower(args[1])
+		if newStatus == current {
+			if output := outputFormat(cmd); output != outputText {
+				printStructured(output, statusOutputFor(project, requirement))
+				return
+			}
+			fmt.Printf("%s is already %s\n", requirement.ID, current)
+			return
+		}
+
+		if err := project.ValidateTransition(current, newStatus); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		requirement.Status = newStatus
+		requirement.Touch()
+
+		// Save project
+		if err := project.Save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+			os.Exit(1)
+		}
+
+		if output := outputFormat(cmd); output != outputText {
+			printStructured(output, statusOutputFor(project, requirement))
+			return
+		}
+		fmt.Printf("%s: %s -> %s\n", requirement.ID, current, newStatus)
+	},
+}
+
+// statusOutput is the structured output of `reqd status`, with the new status after a change
+type statusOutput struct {
+	ID          string   `json:"id" yaml:"id"`
+	UID         string   `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Status      string   `json:"status" yaml:"status"`
+	Transitions []string `json:"transitions" yaml:"transitions"`
+}
+
+// statusOutputFor prepares the structured output of a requirement's current status
+func statusOutputFor(project *types.Project, requirement *types.Requirement) statusOutput {
+	status := requirement.CurrentStatus()
+	return statusOutput{
+		ID:          requirement.ID,
+		UID:         requirement.UID,
+		Status:      status,
+		Transitions: nonNil(project.StatusTransitions()[status]),
+	}
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+var TagCmd = &cobra.Command{
+	Use:   "tag [requirement_id] [add|remove] [tag...]",
+	Short: "Add or remove tags on a requirement",
+	Long: `Add or remove free-form tags such as security, performance or compliance on a requirement,
+or list its tags when no action is given. Tags are case-insensitive.`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{structuredOutput: "true"},
+	Run: func(cmd *cobra.Command, args []string) {
+		requirementID := args[0]
+
+		// Load existing project
+		project, err := types.LoadProject()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: No requirements.yaml found. Run 'reqd init' first.\n")
+			os.Exit(1)
+		}
+
+		requirement := project.FindRequirement(requirementID)
+		if requirement == nil {
+			fmt.Fprintf(os.Stderr, "Error: Requirement '%s' not found\n", requirementID)
+			os.Exit(1)
+		}
+
+		if len(args) > 1 {
+			action, tags := args[1], args[2:]
+			if len(tags) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: No tags given\n")
+				os.Exit(1)
+			}
+
+			changed := false
+			for _, tag := range tags {
+				switch action {
+				case "add":
+					changed = requirement.AddTag(tag) || changed
+				case "remove":
+					changed = requirement.RemoveTag(tag) || changed
+				default:
+					fmt.Fprintf(os.Stderr, "Error: Unknown action '%s' (expected add or remove)\n", action)
+					os.Exit(1)
+				}
+			}
+
+			if changed {
+				requirement.Touch()
+
+				// Save project
+				if err := project.Save(); err != nil {
+					fmt.Fprintf(os.Stderr, "Error saving requirements: %v\n", err)
+					os.Exit(1)
+				}
+			}
+		}
+
+		if output := outputFormat(cmd); output != outputText {
+			printStructured(output, tagOutput{ID: requirement.ID, UID: requirement.UID, Tags: nonNil(requirement.Tags)})
+			return
+		}
+
+		if len(requirement.Tags) == 0 {
+			fmt.Printf("%s: no tags\n", requirement.ID)
+			return
+		}
+		fmt.Printf("%s: %s\n", requirement.ID, strings.Join(requirement.Tags, ", "))
+	},
+}
+
+// tagOutput is the structured output of `reqd tag`
+type tagOutput struct {
+	ID   string   `json:"id" yaml:"id"`
+	UID  string   `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Tags []string `json:"tags" yaml:"tags"`
+}
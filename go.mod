@@ -4,10 +4,12 @@ go 1.24.3
 
 require (
 	github.com/spf13/cobra v1.9.1
+	golang.org/x/term v0.40.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/spf13/pflag v1.0.7 // indirect
+	golang.org/x/sys v0.41.0 // indirect
 )
@@ -0,0 +1,214 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/techcorrectco/reqd/internal"
+	"github.com/techcorrectco/reqd/internal/cache"
+	"github.com/techcorrectco/reqd/internal/config"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+type ValidationResponse struct {
+	Input       string   `json:"input"`
+	Problems    []string `json:"problems"`
+	Recommended string   `json:"recommended"`
+}
+
+type ParentProposalResponse struct {
+	ProposedParent *string `json:"proposed_parent"`
+}
+
+type AcceptanceCriteriaResponse struct {
+	Criteria []string `json:"criteria"`
+}
+
+// Kinds of findings reported by FindConflicts
+const (
+	FindingContradiction       = "contradiction"
+	FindingOverlap             = "overlap"
+	FindingMissingPrecondition = "missing_precondition"
+)
+
+type ConflictsResponse struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a problem between requirements, referencing them by positional ID
+type Finding struct {
+	Type         string   `json:"type"`
+	Requirements []string `json:"requirements"`
+	Description  string   `json:"description"`
+}
+
+// renderTemplate renders a template string with provided data
+func renderTemplate(templateStr string, data map[string]string) (string, error) {
+	tmpl, err := template.New("prompt").Parse(templateStr)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute prompt template: %w", err)
+	}
+
+	return buffer.String(), nil
+}
+
+// CacheEnabled controls whether responses are read from and written to the on-disk cache
+var CacheEnabled = true
+
+// complete renders a prompt and sends it to the configured provider, returning the response content.
+// Responses are cached by provider, endpoint, model, temperature, prompt template and input.
+func complete(ctx context.Context, templateStr string, data map[string]string) (string, error) {
+	// Render template
+	prompt, err := renderTemplate(templateStr, data)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return "", err
+	}
+
+	provider, err := NewProvider(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	responseCache := openCache(cfg)
+	input, _ := json.Marshal(data)
+	temperature := strconv.FormatFloat(effectiveTemperature(cfg), 'g', -1, 64)
+	key := cache.Key(provider.Name(), cfg.BaseURL, provider.Model(), temperature, cache.Key(templateStr), string(input))
+	if responseCache != nil {
+		if cached, ok := responseCache.Get(key); ok {
+			return cached, nil
+		}
+	}
+
+	// Make provider request
+	responseContent, err := provider.Complete(ctx, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	responseContent = extractJSON(responseContent)
+
+	// Only cache answers that can be parsed; a failed write just means a cache miss next time
+	if responseCache != nil && json.Valid([]byte(responseContent)) {
+		responseCache.Put(key, responseContent)
+	}
+
+	return responseContent, nil
+}
+
+// openCache returns the response cache, or nil when caching is disabled or unavailable
+func openCache(cfg *config.Config) *cache.Cache {
+	if !CacheEnabled {
+		return nil
+	}
+	dir, err := cache.DefaultDir()
+	if err != nil {
+		return nil
+	}
+	return cache.New(dir, cfg.CacheTTL)
+}
+
+// extractJSON returns the outermost JSON object in a response, dropping any surrounding
+// prose or Markdown code fences that providers without a JSON mode may add
+func extractJSON(content string) string {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return content
+	}
+	return content[start : end+1]
+}
+
+func ValidateRequirement(ctx context.Context, input string) (*ValidationResponse, error) {
+	responseContent, err := complete(ctx, internal.ValidateRequirementPrompt, map[string]string{"Input": input})
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the JSON content from the provider
+	var validationResp ValidationResponse
+	if err := json.Unmarshal([]byte(responseContent), &validationResp); err != nil {
+		return nil, fmt.Errorf("failed to parse validation response JSON: %w", err)
+	}
+
+	return &validationResp, nil
+}
+
+func ProposeParent(ctx context.Context, requirement string, branches []types.Requirement) (*ParentProposalResponse, error) {
+	// Format branches using DisplayFormat method
+	var parentsText string
+	for _, branch := range branches {
+		parentsText += branch.DisplayFormat() + "\n"
+	}
+
+	responseContent, err := complete(ctx, internal.ProposeParentPrompt, map[string]string{
+		"Parents":     parentsText,
+		"Requirement": requirement,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the JSON content from the provider
+	var proposalResp ParentProposalResponse
+	if err := json.Unmarshal([]byte(responseContent), &proposalResp); err != nil {
+		return nil, fmt.Errorf("failed to parse parent proposal response JSON: %w", err)
+	}
+
+	return &proposalResp, nil
+}
+
+func DraftAcceptanceCriteria(ctx context.Context, requirement string) (*AcceptanceCriteriaResponse, error) {
+	responseContent, err := complete(ctx, internal.DraftAcceptanceCriteriaPrompt, map[string]string{"Requirement": requirement})
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the JSON content from the provider
+	var criteriaResp AcceptanceCriteriaResponse
+	if err := json.Unmarshal([]byte(responseContent), &criteriaResp); err != nil {
+		return nil, fmt.Errorf("failed to parse acceptance criteria response JSON: %w", err)
+	}
+
+	return &criteriaResp, nil
+}
+
+// FindConflicts reviews a group of related requirements together for contradictions, overlaps and
+// missing preconditions
+func FindConflicts(ctx context.Context, requirements []*types.Requirement) (*ConflictsResponse, error) {
+	var requirementsText string
+	for _, req := range requirements {
+		requirementsText += req.DisplayFormat() + "\n"
+		for _, criterion := range req.Acceptance {
+			requirementsText += "  - " + criterion + "\n"
+		}
+	}
+
+	responseContent, err := complete(ctx, internal.FindConflictsPrompt, map[string]string{"Requirements": requirementsText})
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the JSON content from the provider
+	var conflictsResp ConflictsResponse
+	if err := json.Unmarshal([]byte(responseContent), &conflictsResp); err != nil {
+		return nil, fmt.Errorf("failed to parse conflicts response JSON: %w", err)
+	}
+
+	return &conflictsResp, nil
+}
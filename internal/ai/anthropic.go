@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// anthropicVersion is the Messages API version sent with every request
+const anthropicVersion = "2023-06-01"
+
+// AnthropicProvider talks to Anthropic's Messages API
+type AnthropicProvider struct {
+	BaseURL     string
+	APIKey      string
+	ModelName   string
+	Temperature float64
+	MaxTokens   int
+	Transport   Transport
+}
+
+type AnthropicRequest struct {
+	Model       string    `json:"model"`
+	MaxTokens   int       `json:"max_tokens"`
+	Messages    []Message `json:"messages"`
+	Temperature float64   `json:"temperature"`
+}
+
+type AnthropicResponse struct {
+	Content []AnthropicContent `json:"content"`
+}
+
+type AnthropicContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Name returns the provider name
+func (p *AnthropicProvider) Name() string {
+	return ProviderAnthropic
+}
+
+// Model returns the model name
+func (p *AnthropicProvider) Model() string {
+	return p.ModelName
+}
+
+// Complete sends the prompt as a single user message and returns the text of the response
+func (p *AnthropicProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	reqBody := AnthropicRequest{
+		Model:     p.ModelName,
+		MaxTokens: p.MaxTokens,
+		Messages: []Message{
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+		Temperature: p.Temperature,
+	}
+
+	headers := map[string]string{
+		"x-api-key":         p.APIKey,
+		"anthropic-version": anthropicVersion,
+	}
+
+	var anthropicResp AnthropicResponse
+	url := strings.TrimSuffix(p.BaseURL, "/") + "/messages"
+	if err := p.Transport.postJSON(ctx, "Anthropic", url, headers, reqBody, &anthropicResp); err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	for _, content := range anthropicResp.Content {
+		if content.Type == "text" {
+			text.WriteString(content.Text)
+		}
+	}
+
+	if text.Len() == 0 {
+		return "", fmt.Errorf("no text content in Anthropic response")
+	}
+
+	return text.String(), nil
+}
@@ -0,0 +1,192 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/techcorrectco/reqd/internal/cache"
+	"github.com/techcorrectco/reqd/internal/config"
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// DefaultDuplicateThreshold is the similarity above which a new requirement is reported as a
+// likely duplicate when no threshold is configured
+const DefaultDuplicateThreshold = 0.85
+
+// embeddingBatchSize is the number of texts sent to the provider in one embeddings request,
+// well below the input limits of the OpenAI API
+const embeddingBatchSize = 256
+
+// Similarity is a requirement scored by how closely its text resembles another text
+type Similarity struct {
+	Requirement *types.Requirement
+	// Score is the cosine similarity of the two embeddings, 1 for identical meaning
+	Score float64
+}
+
+// DefaultEmbedder returns the configured provider when it supports embeddings
+func DefaultEmbedder() (Embedder, error) {
+	provider, err := DefaultProvider()
+	if err != nil {
+		return nil, err
+	}
+	embedder, ok := provider.(Embedder)
+	if !ok {
+		return nil, fmt.Errorf("the %s provider does not support embeddings", provider.Name())
+	}
+	return embedder, nil
+}
+
+// EmbeddingsAvailable reports whether the configured provider can compute embeddings, warning
+// about configuration errors as Available does
+func EmbeddingsAvailable() bool {
+	provider, err := DefaultProvider()
+	warnIfMisconfigured(err)
+	if err != nil {
+		return false
+	}
+	_, ok := provider.(Embedder)
+	return ok
+}
+
+// DuplicateThreshold returns the configured duplicate threshold, or DefaultDuplicateThreshold
+func DuplicateThreshold() (float64, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return 0, err
+	}
+	if cfg.DuplicateThreshold == nil {
+		return DefaultDuplicateThreshold, nil
+	}
+	return *cfg.DuplicateThreshold, nil
+}
+
+// Embed returns an embedding for each text from the configured provider. Embeddings are cached
+// by provider, endpoint, embedding model and text, so only new or changed texts are sent to the provider.
+// Uncached texts are sent in batches, each cached as soon as it is embedded.
+func Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := NewProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+	embedder, ok := provider.(Embedder)
+	if !ok {
+		return nil, fmt.Errorf("the %s provider does not support embeddings", provider.Name())
+	}
+
+	embeddingCache := openCache(cfg)
+	key := func(text string) string {
+		return cache.Key("embedding", provider.Name(), cfg.BaseURL, embedder.EmbeddingModel(), text)
+	}
+
+	vectors := make([][]float64, len(texts))
+	var missing []int
+	for i, text := range texts {
+		if embeddingCache != nil {
+			if cached, ok := embeddingCache.Get(key(text)); ok && json.Unmarshal([]byte(cached), &vectors[i]) == nil {
+				continue
+			}
+		}
+		missing = append(missing, i)
+	}
+	if len(missing) == 0 {
+		return vectors, nil
+	}
+
+	// Embed every text that is not cached, skipping duplicates
+	var pending []string
+	seen := make(map[string]bool)
+	for _, i := range missing {
+		if !seen[texts[i]] {
+			seen[texts[i]] = true
+			pending = append(pending, texts[i])
+		}
+	}
+
+	embedded := make(map[string][]float64, len(pending))
+	for batch := range slices.Chunk(pending, embeddingBatchSize) {
+		batchVectors, err := embedder.Embed(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(batchVectors) != len(batch) {
+			return nil, fmt.Errorf("provider returned %d embeddings for %d texts", len(batchVectors), len(batch))
+		}
+
+		for j, text := range batch {
+			embedded[text] = batchVectors[j]
+			// A failed write just means the text is embedded again next time
+			if embeddingCache != nil {
+				if data, err := json.Marshal(batchVectors[j]); err == nil {
+					embeddingCache.Put(key(text), string(data))
+				}
+			}
+		}
+	}
+
+	for _, i := range missing {
+		vectors[i] = embedded[texts[i]]
+	}
+	return vectors, nil
+}
+
+// RankBySimilarity scores every requirement by the similarity of its text to text and returns
+// them with the most similar first
+func RankBySimilarity(ctx context.Context, text string, requirements []*types.Requirement) ([]Similarity, error) {
+	if len(requirements) == 0 {
+		return nil, nil
+	}
+
+	texts := []string{text}
+	for _, req := range requirements {
+		texts = append(texts, req.Text)
+	}
+
+	vectors, err := Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := make([]Similarity, len(requirements))
+	for i, req := range requirements {
+		ranked[i] = Similarity{Requirement: req, Score: CosineSimilarity(vectors[0], vectors[i+1])}
+	}
+	slices.SortStableFunc(ranked, func(a, b Similarity) int {
+		switch {
+		case a.Score > b.Score:
+			return -1
+		case a.Score < b.Score:
+			return 1
+		}
+		return 0
+	})
+
+	return ranked, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between two vectors, or 0 when they differ in
+// length or either is zero
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
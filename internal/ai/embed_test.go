@@ -0,0 +1,196 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+func TestProviders_Embed(t *testing.T) {
+	want := [][]float64{{1, 0}, {0, 1}}
+
+	tests := []struct {
+		name     string
+		provider func(baseURL string) Embedder
+		path     string
+		response any
+	}{
+		{
+			name: "openai",
+			provider: func(baseURL string) Embedder {
+				return &OpenAIProvider{BaseURL: baseURL + "/v1", APIKey: "key", EmbeddingModelName: "text-embedding-3-small"}
+			},
+			path: "/v1/embeddings",
+			// Entries are placed by index, not by position
+			response: OpenAIEmbeddingResponse{Data: []OpenAIEmbedding{{Index: 1, Embedding: want[1]}, {Index: 0, Embedding: want[0]}}},
+		},
+		{
+			name: "ollama",
+			provider: func(baseURL string) Embedder {
+				return &OllamaProvider{BaseURL: baseURL, EmbeddingModelName: "nomic-embed-text"}
+			},
+			path:     "/api/embed",
+			response: OllamaEmbedResponse{Embeddings: want},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.path, nil, tt.response)
+
+			vectors, err := tt.provider(server.URL).Embed(context.Background(), []string{"a", "b"})
+			if err != nil {
+				t.Fatalf("Embed() error = %v", err)
+			}
+			if !reflect.DeepEqual(vectors, want) {
+				t.Errorf("Embed() = %v, want %v", vectors, want)
+			}
+		})
+	}
+}
+
+func TestRankBySimilarity_CachesEmbeddings(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("REQD_CACHE_DIR", t.TempDir())
+
+	vectors := map[string][]float64{
+		"log in":                     {1, 0},
+		"Users MUST log in":          {0.9, 0.1},
+		"Reports MUST be exportable": {0, 1},
+	}
+	var inputs [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OpenAIEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		inputs = append(inputs, req.Input)
+
+		var resp OpenAIEmbeddingResponse
+		for i, text := range req.Input {
+			resp.Data = append(resp.Data, OpenAIEmbedding{Index: i, Embedding: vectors[text]})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("REQD_PROVIDER", ProviderOpenAI)
+	t.Setenv("OPENAI_API_KEY", "key")
+	t.Setenv("REQD_BASE_URL", server.URL+"/v1")
+
+	reports := &types.Requirement{ID: "1", Text: "Reports MUST be exportable"}
+	login := &types.Requirement{ID: "2", Text: "Users MUST log in"}
+	ranked, err := RankBySimilarity(context.Background(), "log in", []*types.Requirement{reports, login})
+	if err != nil {
+		t.Fatalf("RankBySimilarity() error = %v", err)
+	}
+	if len(ranked) != 2 || ranked[0].Requirement != login || ranked[0].Score < 0.9 || ranked[1].Score != 0 {
+		t.Errorf("RankBySimilarity() = %+v, want the login requirement first", ranked)
+	}
+
+	// Only texts without a cached embedding are sent again
+	vectors["Users MUST sign in"] = []float64{0.8, 0.2}
+	login.Text = "Users MUST sign in"
+	if _, err := RankBySimilarity(context.Background(), "log in", []*types.Requirement{reports, login}); err != nil {
+		t.Fatalf("RankBySimilarity() error = %v", err)
+	}
+	want := [][]string{{"log in", "Reports MUST be exportable", "Users MUST log in"}, {"Users MUST sign in"}}
+	if !reflect.DeepEqual(inputs, want) {
+		t.Errorf("embedded inputs = %v, want %v", inputs, want)
+	}
+}
+
+func TestEmbed_Unsupported(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("REQD_PROVIDER", ProviderAnthropic)
+	t.Setenv("ANTHROPIC_API_KEY", "key")
+
+	if _, err := Embed(context.Background(), []string{"text"}); err == nil {
+		t.Error("Embed() with the anthropic provider succeeded, want error")
+	}
+	if EmbeddingsAvailable() {
+		t.Error("EmbeddingsAvailable() = true for the anthropic provider, want false")
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []float64
+		want float64
+	}{
+		{"identical", []float64{1, 2, 3}, []float64{1, 2, 3}, 1},
+		{"scaled", []float64{1, 2}, []float64{2, 4}, 1},
+		{"orthogonal", []float64{1, 0}, []float64{0, 1}, 0},
+		{"opposite", []float64{1, 0}, []float64{-1, 0}, -1},
+		{"zero vector", []float64{0, 0}, []float64{1, 0}, 0},
+		{"different lengths", []float64{1}, []float64{1, 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CosineSimilarity(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CosineSimilarity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmbed_Batches(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("REQD_CACHE_DIR", t.TempDir())
+
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OpenAIEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		batchSizes = append(batchSizes, len(req.Input))
+
+		var resp OpenAIEmbeddingResponse
+		for i := range req.Input {
+			resp.Data = append(resp.Data, OpenAIEmbedding{Index: i, Embedding: []float64{1, float64(i)}})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	t.Setenv("REQD_PROVIDER", ProviderOpenAI)
+	t.Setenv("OPENAI_API_KEY", "key")
+	t.Setenv("REQD_BASE_URL", server.URL+"/v1")
+
+	texts := make([]string, embeddingBatchSize+10)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("The system MUST handle case %d", i)
+	}
+
+	vectors, err := Embed(context.Background(), texts)
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vectors) != len(texts) {
+		t.Fatalf("Embed() returned %d vectors, want %d", len(vectors), len(texts))
+	}
+	if want := []int{embeddingBatchSize, 10}; !reflect.DeepEqual(batchSizes, want) {
+		t.Errorf("batch sizes = %v, want %v", batchSizes, want)
+	}
+
+	// Every batch was cached
+	if _, err := Embed(context.Background(), texts); err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(batchSizes) != 2 {
+		t.Errorf("server received %d requests, want 2 with cached embeddings", len(batchSizes))
+	}
+}
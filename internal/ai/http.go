@@ -0,0 +1,190 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default retry settings used when a Transport leaves them unset
+const (
+	defaultMaxRetries = 3
+	defaultBaseDelay  = time.Second
+	defaultMaxDelay   = 30 * time.Second
+)
+
+// Transport holds the HTTP settings shared by every provider
+type Transport struct {
+	Client *http.Client
+	// MaxRetries is how often a request is retried after a 429, a 5xx or a network error
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles with every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff and any Retry-After delay requested by the server
+	MaxDelay time.Duration
+}
+
+// RetryEvent describes a failed attempt that is about to be retried
+type RetryEvent struct {
+	Provider string
+	Attempt  int
+	Attempts int
+	Reason   string
+	Wait     time.Duration
+}
+
+// OnRetry, when set, is called before every retry so that callers can report it
+var OnRetry func(event RetryEvent)
+
+// retryableError is a failed attempt that may succeed when repeated
+type retryableError struct {
+	reason     string
+	retryAfter time.Duration
+	err        error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// postJSON sends body as JSON to url with the given headers and decodes a successful response
+// into result, retrying rate-limited, failed and interrupted requests with exponential backoff
+func (t Transport) postJSON(ctx context.Context, providerName, url string, headers map[string]string, body, result any) error {
+	jsonData, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	attempts := t.MaxRetries + 1
+	var reasons []string
+	for attempt := 1; ; attempt++ {
+		err := t.attempt(ctx, providerName, url, headers, jsonData, result)
+
+		var retryable *retryableError
+		if err == nil || !errors.As(err, &retryable) {
+			return err
+		}
+
+		reasons = append(reasons, retryable.reason)
+		if attempt >= attempts {
+			if attempts > 1 {
+				return fmt.Errorf("%w (gave up after %d attempts: %s)", err, attempts, strings.Join(reasons, ", "))
+			}
+			return err
+		}
+
+		wait := t.backoff(attempt, retryable.retryAfter)
+		if OnRetry != nil {
+			OnRetry(RetryEvent{
+				Provider: providerName,
+				Attempt:  attempt,
+				Attempts: attempts,
+				Reason:   retryable.reason,
+				Wait:     wait,
+			})
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s request cancelled: %w", providerName, ctx.Err())
+		case <-time.After(wait):
+		}
+	}
+}
+
+// attempt makes a single request, marking failures that are worth retrying
+func (t Transport) attempt(ctx context.Context, providerName, url string, headers map[string]string, jsonData []byte, result any) error {
+	// Make HTTP request
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	client := t.Client
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s request cancelled: %w", providerName, ctx.Err())
+		}
+		// Connection failures and timeouts are usually transient
+		return &retryableError{reason: "network error", err: fmt.Errorf("failed to make request: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		err := fmt.Errorf("%s API error (status %d): %s", providerName, resp.StatusCode, string(body))
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+			return &retryableError{
+				reason:     fmt.Sprintf("status %d", resp.StatusCode),
+				retryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+				err:        err,
+			}
+		}
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", providerName, err)
+	}
+
+	return nil
+}
+
+// backoff returns how long to wait before the given retry: the server's Retry-After when present,
+// otherwise an exponentially growing delay with full jitter
+func (t Transport) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	baseDelay := t.BaseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultBaseDelay
+	}
+	maxDelay := t.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	if retryAfter > 0 {
+		return min(retryAfter, maxDelay)
+	}
+
+	// Stop doubling once the delay reaches maxDelay, before the shift can overflow
+	delay := maxDelay
+	if shift := attempt - 1; shift < 62 && baseDelay <= maxDelay>>shift {
+		delay = baseDelay << shift
+	}
+	return rand.N(delay) + 1
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return 0
+}
@@ -0,0 +1,171 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransport_postJSON_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		retryAfter   string
+		maxRetries   int
+		wantErr      bool
+		wantAttempts int
+		wantRetries  []string
+	}{
+		{
+			name:         "success without retry",
+			statuses:     []int{200},
+			maxRetries:   3,
+			wantAttempts: 1,
+		},
+		{
+			name:         "rate limited then success",
+			statuses:     []int{429, 200},
+			retryAfter:   "1",
+			maxRetries:   3,
+			wantAttempts: 2,
+			wantRetries:  []string{"status 429"},
+		},
+		{
+			name:         "server errors then success",
+			statuses:     []int{503, 502, 200},
+			maxRetries:   3,
+			wantAttempts: 3,
+			wantRetries:  []string{"status 503", "status 502"},
+		},
+		{
+			name:         "gives up after max retries",
+			statuses:     []int{500, 500, 500},
+			maxRetries:   2,
+			wantErr:      true,
+			wantAttempts: 3,
+			wantRetries:  []string{"status 500", "status 500"},
+		},
+		{
+			name:         "client error is not retried",
+			statuses:     []int{400, 200},
+			maxRetries:   3,
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[attempts]
+				attempts++
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				w.Write([]byte(`{"ok": true}`))
+			}))
+			defer server.Close()
+
+			var retries []string
+			var waits []time.Duration
+			OnRetry = func(event RetryEvent) {
+				retries = append(retries, event.Reason)
+				waits = append(waits, event.Wait)
+			}
+			defer func() { OnRetry = nil }()
+
+			transport := Transport{MaxRetries: tt.maxRetries, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+			var result map[string]bool
+			err := transport.postJSON(context.Background(), "Test", server.URL, nil, map[string]string{}, &result)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("postJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("postJSON() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+			if len(retries) != len(tt.wantRetries) {
+				t.Fatalf("postJSON() retried %v, want %v", retries, tt.wantRetries)
+			}
+			for i := range retries {
+				if retries[i] != tt.wantRetries[i] {
+					t.Errorf("retry %d reason = %q, want %q", i, retries[i], tt.wantRetries[i])
+				}
+				if waits[i] > 5*time.Millisecond {
+					t.Errorf("retry %d wait = %v, want at most MaxDelay", i, waits[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTransport_postJSON_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	OnRetry = func(event RetryEvent) { cancel() }
+	defer func() { OnRetry = nil }()
+
+	transport := Transport{MaxRetries: 5, BaseDelay: time.Minute, MaxDelay: time.Minute}
+	var result map[string]any
+	err := transport.postJSON(ctx, "Test", server.URL, nil, map[string]string{}, &result)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("postJSON() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestTransport_backoff(t *testing.T) {
+	tests := []struct {
+		name       string
+		transport  Transport
+		attempt    int
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{name: "first retry", transport: Transport{BaseDelay: time.Second, MaxDelay: 30 * time.Second}, attempt: 1, want: time.Second},
+		{name: "doubles", transport: Transport{BaseDelay: time.Second, MaxDelay: 30 * time.Second}, attempt: 3, want: 4 * time.Second},
+		{name: "capped", transport: Transport{BaseDelay: time.Second, MaxDelay: 30 * time.Second}, attempt: 10, want: 30 * time.Second},
+		{name: "large attempt does not overflow", transport: Transport{}, attempt: 35, want: defaultMaxDelay},
+		{name: "huge attempt does not overflow", transport: Transport{}, attempt: 1000, want: defaultMaxDelay},
+		{name: "retry after", transport: Transport{}, attempt: 1, retryAfter: 7 * time.Second, want: 7 * time.Second},
+		{name: "retry after is capped", transport: Transport{}, attempt: 1, retryAfter: time.Hour, want: defaultMaxDelay},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.transport.backoff(tt.attempt, tt.retryAfter)
+			if tt.retryAfter > 0 {
+				if got != tt.want {
+					t.Errorf("backoff() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			// Without Retry-After the delay is jittered up to the exponential bound
+			if got <= 0 || got > tt.want {
+				t.Errorf("backoff() = %v, want between 0 and %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("7"); got != 7*time.Second {
+		t.Errorf("parseRetryAfter(\"7\") = %v, want 7s", got)
+	}
+	if got := parseRetryAfter(""); got != 0 {
+		t.Errorf("parseRetryAfter(\"\") = %v, want 0", got)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got < 59*time.Minute || got > time.Hour {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 1h", date, got)
+	}
+}
@@ -0,0 +1,103 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OllamaProvider talks to a local Ollama server through its native chat API
+type OllamaProvider struct {
+	BaseURL     string
+	ModelName   string
+	Temperature float64
+	MaxTokens   int
+	Transport   Transport
+	// EmbeddingModelName is the model used by Embed
+	EmbeddingModelName string
+}
+
+type OllamaRequest struct {
+	Model    string         `json:"model"`
+	Messages []Message      `json:"messages"`
+	Format   string         `json:"format,omitempty"`
+	Stream   bool           `json:"stream"`
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+type OllamaResponse struct {
+	Message Message `json:"message"`
+}
+
+type OllamaEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type OllamaEmbedResponse struct {
+	Embeddings [][]float64 `json:"embeddings"`
+}
+
+// Name returns the provider name
+func (p *OllamaProvider) Name() string {
+	return ProviderOllama
+}
+
+// Model returns the model name
+func (p *OllamaProvider) Model() string {
+	return p.ModelName
+}
+
+// Complete sends the prompt as a non-streaming chat request in JSON mode and returns the response content
+func (p *OllamaProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	reqBody := OllamaRequest{
+		Model: p.ModelName,
+		Messages: []Message{
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+		Format: "json",
+		Stream: false,
+		Options: map[string]any{
+			"temperature": p.Temperature,
+		},
+	}
+	if p.MaxTokens > 0 {
+		reqBody.Options["num_predict"] = p.MaxTokens
+	}
+
+	var ollamaResp OllamaResponse
+	url := strings.TrimSuffix(p.BaseURL, "/") + "/api/chat"
+	if err := p.Transport.postJSON(ctx, "Ollama", url, nil, reqBody, &ollamaResp); err != nil {
+		return "", err
+	}
+
+	return ollamaResp.Message.Content, nil
+}
+
+// EmbeddingModel returns the model used by Embed
+func (p *OllamaProvider) EmbeddingModel() string {
+	return p.EmbeddingModelName
+}
+
+// Embed returns an embedding vector for each text from the native embed API
+func (p *OllamaProvider) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	reqBody := OllamaEmbedRequest{
+		Model: p.EmbeddingModelName,
+		Input: texts,
+	}
+
+	var embedResp OllamaEmbedResponse
+	url := strings.TrimSuffix(p.BaseURL, "/") + "/api/embed"
+	if err := p.Transport.postJSON(ctx, "Ollama", url, nil, reqBody, &embedResp); err != nil {
+		return nil, err
+	}
+
+	if len(embedResp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("Ollama returned %d embeddings for %d inputs", len(embedResp.Embeddings), len(texts))
+	}
+
+	return embedResp.Embeddings, nil
+}
@@ -0,0 +1,145 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OpenAIProvider talks to the OpenAI chat completions API or any server compatible with it,
+// such as Azure OpenAI gateways or llama.cpp's server
+type OpenAIProvider struct {
+	name        string
+	BaseURL     string
+	APIKey      string
+	ModelName   string
+	Temperature float64
+	MaxTokens   int
+	Transport   Transport
+	// EmbeddingModelName is the model used by Embed
+	EmbeddingModelName string
+}
+
+type OpenAIRequest struct {
+	Model          string            `json:"model"`
+	Messages       []Message         `json:"messages"`
+	ResponseFormat map[string]string `json:"response_format,omitempty"`
+	Temperature    float64           `json:"temperature"`
+	MaxTokens      int               `json:"max_tokens,omitempty"`
+}
+
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type OpenAIResponse struct {
+	Choices []Choice `json:"choices"`
+}
+
+type Choice struct {
+	Message Message `json:"message"`
+}
+
+type OpenAIEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type OpenAIEmbeddingResponse struct {
+	Data []OpenAIEmbedding `json:"data"`
+}
+
+type OpenAIEmbedding struct {
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
+
+// Name returns the provider name
+func (p *OpenAIProvider) Name() string {
+	if p.name == "" {
+		return ProviderOpenAI
+	}
+	return p.name
+}
+
+// Model returns the model name
+func (p *OpenAIProvider) Model() string {
+	return p.ModelName
+}
+
+// Complete sends the prompt as a chat completion and returns the response content
+func (p *OpenAIProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	// Create OpenAI request
+	reqBody := OpenAIRequest{
+		Model: p.ModelName,
+		Messages: []Message{
+			{
+				Role:    "user",
+				Content: prompt,
+			},
+		},
+		ResponseFormat: map[string]string{
+			"type": "json_object",
+		},
+		Temperature: p.Temperature,
+		MaxTokens:   p.MaxTokens,
+	}
+
+	headers := map[string]string{}
+	if p.APIKey != "" {
+		headers["Authorization"] = "Bearer " + p.APIKey
+	}
+
+	// Parse OpenAI response
+	var openaiResp OpenAIResponse
+	url := strings.TrimSuffix(p.BaseURL, "/") + "/chat/completions"
+	if err := p.Transport.postJSON(ctx, "OpenAI", url, headers, reqBody, &openaiResp); err != nil {
+		return "", err
+	}
+
+	if len(openaiResp.Choices) == 0 {
+		return "", fmt.Errorf("no choices in OpenAI response")
+	}
+
+	return openaiResp.Choices[0].Message.Content, nil
+}
+
+// EmbeddingModel returns the model used by Embed
+func (p *OpenAIProvider) EmbeddingModel() string {
+	return p.EmbeddingModelName
+}
+
+// Embed returns an embedding vector for each text from the embeddings endpoint
+func (p *OpenAIProvider) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	reqBody := OpenAIEmbeddingRequest{
+		Model: p.EmbeddingModelName,
+		Input: texts,
+	}
+
+	headers := map[string]string{}
+	if p.APIKey != "" {
+		headers["Authorization"] = "Bearer " + p.APIKey
+	}
+
+	var embeddingResp OpenAIEmbeddingResponse
+	url := strings.TrimSuffix(p.BaseURL, "/") + "/embeddings"
+	if err := p.Transport.postJSON(ctx, "OpenAI", url, headers, reqBody, &embeddingResp); err != nil {
+		return nil, err
+	}
+
+	// Entries carry the index of their input and are not guaranteed to be in order
+	vectors := make([][]float64, len(texts))
+	for _, data := range embeddingResp.Data {
+		if data.Index >= 0 && data.Index < len(vectors) {
+			vectors[data.Index] = data.Embedding
+		}
+	}
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			return nil, fmt.Errorf("no embedding for input %d in OpenAI response", i)
+		}
+	}
+
+	return vectors, nil
+}
@@ -0,0 +1,199 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/techcorrectco/reqd/internal/config"
+)
+
+// Provider sends a prompt to a language model and returns the text of its answer.
+// Prompts ask for a JSON object; providers request JSON output where the API supports it.
+type Provider interface {
+	// Name identifies the provider, e.g. "openai"
+	Name() string
+	// Model identifies the model that answers prompts, e.g. "gpt-4o"
+	Model() string
+	// Complete sends a single-turn prompt and returns the response content
+	Complete(ctx context.Context, prompt string) (string, error)
+}
+
+// Embedder is implemented by providers that can turn text into embedding vectors for semantic search
+type Embedder interface {
+	// EmbeddingModel identifies the model that computes embeddings, e.g. "text-embedding-3-small"
+	EmbeddingModel() string
+	// Embed returns one vector for each of the texts, in the same order
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// Provider names accepted by the provider setting
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderOllama    = "ollama"
+	ProviderLlamaCpp  = "llamacpp"
+)
+
+// Providers lists every supported provider name
+var Providers = []string{ProviderOpenAI, ProviderAnthropic, ProviderOllama, ProviderLlamaCpp}
+
+// defaultTimeout bounds every provider request unless the configuration sets another timeout
+const defaultTimeout = 60 * time.Second
+
+// defaultMaxTokens caps response length for providers that require a limit
+const defaultMaxTokens = 1024
+
+// DefaultProvider returns the provider selected by the configuration (.reqd.yaml, the user
+// config file or REQD_* environment variables), defaulting to OpenAI
+func DefaultProvider() (Provider, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	return NewProvider(cfg)
+}
+
+// NewProvider creates the configured provider, filling unset settings with the provider's defaults
+func NewProvider(cfg *config.Config) (Provider, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.Provider))
+	if name == "" {
+		name = ProviderOpenAI
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	maxRetries := defaultMaxRetries
+	if cfg.MaxRetries != nil {
+		maxRetries = *cfg.MaxRetries
+	}
+	transport := Transport{
+		Client:     &http.Client{Timeout: timeout},
+		MaxRetries: maxRetries,
+	}
+
+	temperature := effectiveTemperature(cfg)
+
+	switch name {
+	case ProviderOpenAI:
+		apiKey := os.Getenv("OPENAI_API_KEY")
+		if apiKey == "" {
+			return nil, &MissingAPIKeyError{Variable: "OPENAI_API_KEY"}
+		}
+		return &OpenAIProvider{
+			name:        ProviderOpenAI,
+			BaseURL:     orDefault(cfg.BaseURL, "https://api.openai.com/v1"),
+			APIKey:      apiKey,
+			ModelName:   orDefault(cfg.Model, "gpt-4o"),
+			Temperature: temperature,
+			MaxTokens:   cfg.MaxTokens,
+			Transport:   transport,
+
+			EmbeddingModelName: orDefault(cfg.EmbeddingModel, "text-embedding-3-small"),
+		}, nil
+	case ProviderLlamaCpp:
+		// llama.cpp's server speaks the OpenAI chat completions API and needs no key
+		return &OpenAIProvider{
+			name:        ProviderLlamaCpp,
+			BaseURL:     orDefault(cfg.BaseURL, "http://localhost:8080/v1"),
+			APIKey:      os.Getenv("OPENAI_API_KEY"),
+			ModelName:   orDefault(cfg.Model, "default"),
+			Temperature: temperature,
+			MaxTokens:   cfg.MaxTokens,
+			Transport:   transport,
+
+			// The server embeds with the model it was started with
+			EmbeddingModelName: orDefault(cfg.EmbeddingModel, "default"),
+		}, nil
+	case ProviderAnthropic:
+		apiKey := os.Getenv("ANTHROPIC_API_KEY")
+		if apiKey == "" {
+			return nil, &MissingAPIKeyError{Variable: "ANTHROPIC_API_KEY"}
+		}
+		maxTokens := cfg.MaxTokens
+		if maxTokens == 0 {
+			maxTokens = defaultMaxTokens
+		}
+		return &AnthropicProvider{
+			BaseURL:     orDefault(cfg.BaseURL, "https://api.anthropic.com/v1"),
+			APIKey:      apiKey,
+			ModelName:   orDefault(cfg.Model, "claude-sonnet-4-5"),
+			Temperature: temperature,
+			MaxTokens:   maxTokens,
+			Transport:   transport,
+		}, nil
+	case ProviderOllama:
+		return &OllamaProvider{
+			BaseURL:     orDefault(cfg.BaseURL, "http://localhost:11434"),
+			ModelName:   orDefault(cfg.Model, "llama3.1"),
+			Temperature: temperature,
+			MaxTokens:   cfg.MaxTokens,
+			Transport:   transport,
+
+			EmbeddingModelName: orDefault(cfg.EmbeddingModel, "nomic-embed-text"),
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown AI provider '%s' (supported: %s)", name, strings.Join(Providers, ", "))
+}
+
+// effectiveTemperature returns the configured temperature, or 0 for deterministic answers
+func effectiveTemperature(cfg *config.Config) float64 {
+	if cfg.Temperature != nil {
+		return *cfg.Temperature
+	}
+	return 0
+}
+
+// orDefault returns value, or fallback when value is empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// MissingAPIKeyError reports that the API key of the selected provider is not set, which is the
+// expected state when no AI provider is used
+type MissingAPIKeyError struct {
+	// Variable is the environment variable that holds the key, e.g. "OPENAI_API_KEY"
+	Variable string
+}
+
+func (e *MissingAPIKeyError) Error() string {
+	return fmt.Sprintf("%s environment variable not set", e.Variable)
+}
+
+// warnOnce prints a configuration error once however often availability is checked
+var warnOnce sync.Once
+
+// Available reports whether an AI provider is configured, e.g. whether the required API key is set.
+// A configuration error, such as an unknown provider or an invalid setting, is printed as a
+// warning so that AI features are not skipped silently.
+func Available() bool {
+	_, err := DefaultProvider()
+	warnIfMisconfigured(err)
+	return err == nil
+}
+
+// warnIfMisconfigured prints err to stderr unless it is nil or a missing API key
+func warnIfMisconfigured(err error) {
+	if err == nil || isMissingAPIKey(err) {
+		return
+	}
+	warnOnce.Do(func() {
+		fmt.Fprintf(os.Stderr, "Warning: AI provider disabled: %v\n", err)
+	})
+}
+
+// isMissingAPIKey reports whether err means no API key is set rather than a broken configuration
+func isMissingAPIKey(err error) bool {
+	var missing *MissingAPIKeyError
+	return errors.As(err, &missing)
+}
@@ -0,0 +1,231 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/techcorrectco/reqd/internal/config"
+)
+
+// newTestServer returns a server that checks the request path and answers with the given JSON body
+func newTestServer(t *testing.T, path string, check func(r *http.Request), response any) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("request path = %s, want %s", r.URL.Path, path)
+		}
+		if check != nil {
+			check(r)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProviders_Complete(t *testing.T) {
+	const answer = `{"proposed_parent": "1.2"}`
+
+	tests := []struct {
+		name     string
+		provider func(baseURL string) Provider
+		path     string
+		check    func(r *http.Request)
+		response any
+	}{
+		{
+			name: "openai",
+			provider: func(baseURL string) Provider {
+				return &OpenAIProvider{BaseURL: baseURL + "/v1", APIKey: "key", ModelName: "gpt-4o"}
+			},
+			path: "/v1/chat/completions",
+			check: func(r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer key" {
+					t.Errorf("Authorization = %q, want %q", got, "Bearer key")
+				}
+			},
+			response: OpenAIResponse{Choices: []Choice{{Message: Message{Role: "assistant", Content: answer}}}},
+		},
+		{
+			name: "anthropic",
+			provider: func(baseURL string) Provider {
+				return &AnthropicProvider{BaseURL: baseURL + "/v1", APIKey: "key", ModelName: "claude"}
+			},
+			path: "/v1/messages",
+			check: func(r *http.Request) {
+				if got := r.Header.Get("x-api-key"); got != "key" {
+					t.Errorf("x-api-key = %q, want %q", got, "key")
+				}
+				if got := r.Header.Get("anthropic-version"); got != anthropicVersion {
+					t.Errorf("anthropic-version = %q, want %q", got, anthropicVersion)
+				}
+			},
+			response: AnthropicResponse{Content: []AnthropicContent{{Type: "text", Text: answer}}},
+		},
+		{
+			name: "ollama",
+			provider: func(baseURL string) Provider {
+				return &OllamaProvider{BaseURL: baseURL, ModelName: "llama3.1"}
+			},
+			path:     "/api/chat",
+			response: OllamaResponse{Message: Message{Role: "assistant", Content: answer}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.path, tt.check, tt.response)
+
+			result, err := tt.provider(server.URL).Complete(context.Background(), "prompt")
+			if err != nil {
+				t.Fatalf("Complete() error = %v", err)
+			}
+			if result != answer {
+				t.Errorf("Complete() = %q, want %q", result, answer)
+			}
+		})
+	}
+}
+
+func Test_extractJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "plain object", content: `{"a": 1}`, expected: `{"a": 1}`},
+		{name: "code fence", content: "```json\n{\"a\": 1}\n```", expected: `{"a": 1}`},
+		{name: "surrounding prose", content: "Here you go: {\"a\": {\"b\": 2}} Done.", expected: `{"a": {"b": 2}}`},
+		{name: "no object", content: "no json", expected: "no json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := extractJSON(tt.content); result != tt.expected {
+				t.Errorf("extractJSON() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateRequirement_ConfiguredEndpoint(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("REQD_CACHE_DIR", t.TempDir())
+
+	requests := 0
+	validation := `{"input": "users login", "problems": ["Missing RFC 2119 keyword"], "recommended": "The system MUST let users log in."}`
+	server := newTestServer(t, "/gateway/chat/completions", func(r *http.Request) {
+		var req OpenAIRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Model != "internal-model" || req.Temperature != 0.5 || req.MaxTokens != 256 {
+			t.Errorf("request = %+v, want configured model, temperature and max tokens", req)
+		}
+		requests++
+	}, OpenAIResponse{Choices: []Choice{{Message: Message{Role: "assistant", Content: validation}}}})
+
+	t.Setenv("REQD_PROVIDER", ProviderOpenAI)
+	t.Setenv("OPENAI_API_KEY", "key")
+	t.Setenv("REQD_BASE_URL", server.URL+"/gateway")
+	t.Setenv("REQD_MODEL", "internal-model")
+	t.Setenv("REQD_TEMPERATURE", "0.5")
+	t.Setenv("REQD_MAX_TOKENS", "256")
+
+	result, err := ValidateRequirement(context.Background(), "users login")
+	if err != nil {
+		t.Fatalf("ValidateRequirement() error = %v", err)
+	}
+	if result.Recommended != "The system MUST let users log in." {
+		t.Errorf("ValidateRequirement() recommended = %q", result.Recommended)
+	}
+
+	// The same input is answered from the cache
+	if _, err := ValidateRequirement(context.Background(), "users login"); err != nil {
+		t.Fatalf("ValidateRequirement() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want 1 with a cached second answer", requests)
+	}
+
+	// Unless caching is disabled
+	CacheEnabled = false
+	defer func() { CacheEnabled = true }()
+	if _, err := ValidateRequirement(context.Background(), "users login"); err != nil {
+		t.Fatalf("ValidateRequirement() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("server received %d requests, want 2 with caching disabled", requests)
+	}
+}
+
+func Test_isMissingAPIKey(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("ANTHROPIC_API_KEY", "")
+
+	tests := []struct {
+		provider string
+		want     bool
+	}{
+		{ProviderOpenAI, true},
+		{ProviderAnthropic, true},
+		{"gemini", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			_, err := NewProvider(&config.Config{Provider: tt.provider})
+			if err == nil {
+				t.Fatal("NewProvider() error = nil, want an error")
+			}
+			if got := isMissingAPIKey(err); got != tt.want {
+				t.Errorf("isMissingAPIKey(%v) = %v, want %v", err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRequirement_CacheKeyedByEndpointAndTemperature(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("REQD_CACHE_DIR", t.TempDir())
+
+	requests := 0
+	validation := `{"input": "users login", "problems": [], "recommended": "The system MUST let users log in."}`
+	response := OpenAIResponse{Choices: []Choice{{Message: Message{Role: "assistant", Content: validation}}}}
+	count := func(r *http.Request) { requests++ }
+	first := newTestServer(t, "/v1/chat/completions", count, response)
+	second := newTestServer(t, "/v1/chat/completions", count, response)
+
+	t.Setenv("REQD_PROVIDER", ProviderOpenAI)
+	t.Setenv("OPENAI_API_KEY", "key")
+
+	steps := []struct {
+		name        string
+		baseURL     string
+		temperature string
+		want        int
+	}{
+		{name: "first request", baseURL: first.URL + "/v1", want: 1},
+		{name: "cached", baseURL: first.URL + "/v1", want: 1},
+		{name: "explicit default temperature is cached", baseURL: first.URL + "/v1", temperature: "0", want: 1},
+		{name: "other endpoint", baseURL: second.URL + "/v1", want: 2},
+		{name: "other temperature", baseURL: second.URL + "/v1", temperature: "0.7", want: 3},
+	}
+
+	for _, step := range steps {
+		t.Setenv("REQD_BASE_URL", step.baseURL)
+		t.Setenv("REQD_TEMPERATURE", step.temperature)
+		if _, err := ValidateRequirement(context.Background(), "users login"); err != nil {
+			t.Fatalf("%s: ValidateRequirement() error = %v", step.name, err)
+		}
+		if requests != step.want {
+			t.Errorf("%s: servers received %d requests, want %d", step.name, requests, step.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"slices"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// Group is a set of related requirements that are analyzed together, in tree order
+type Group []*types.Requirement
+
+// SubtreeGroups packs sibling subtrees into groups of at most maxSize requirements, so that
+// requirements that share a parent are analyzed together. A subtree larger than maxSize is split
+// over its children, and the requirement at its root joins the first group of its descendants
+// that has room for it. Groups of a single requirement are dropped since there is nothing to
+// compare it with.
+func SubtreeGroups(requirements []types.Requirement, maxSize int) []Group {
+	return packSubtrees(requirements, maxSize, nil)
+}
+
+// packSubtrees packs sibling subtrees into groups. lead holds the ancestors that are not in a group
+// yet; they start the first group that has room for them, or form a group of their own when none
+// has.
+func packSubtrees(requirements []types.Requirement, maxSize int, lead Group) []Group {
+	var groups []Group
+	var current Group
+	flush := func() {
+		if len(current) > 1 {
+			groups = append(groups, current)
+		}
+		current = nil
+	}
+
+	for i := range requirements {
+		req := &requirements[i]
+		subtree := flatten(req)
+
+		if len(subtree) > maxSize {
+			flush()
+			// Pass the ancestors on so that they can join a group of req's descendants
+			next := Group{req}
+			if len(lead) < maxSize {
+				next, lead = append(slices.Clone(lead), req), nil
+			}
+			groups = append(groups, packSubtrees(req.Children, maxSize, next)...)
+			continue
+		}
+
+		if len(current)+len(subtree) > maxSize {
+			flush()
+		}
+		if len(current) == 0 && len(lead) > 0 && len(lead)+len(subtree) <= maxSize {
+			current, lead = lead, nil
+		}
+		current = append(current, subtree...)
+	}
+	flush()
+
+	// No group had room for the ancestors, so they are analyzed on their own, ahead of their
+	// descendants
+	if len(lead) > 1 {
+		groups = slices.Insert(groups, 0, lead)
+	}
+	return groups
+}
+
+// flatten returns a requirement and its descendants in tree order
+func flatten(req *types.Requirement) Group {
+	var group Group
+	req.Walk(func(r *types.Requirement) {
+		group = append(group, r)
+	})
+	return group
+}
+
+// SimilarityGroups groups requirements whose embeddings are at least threshold similar, directly
+// or through other requirements, according to similarity. Groups larger than maxSize are split
+// and groups of a single requirement are dropped.
+func SimilarityGroups(requirements []*types.Requirement, similarity func(i, j int) float64, threshold float64, maxSize int) []Group {
+	// Union-find over the requirement indices
+	parent := make([]int, len(requirements))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i := range requirements {
+		for j := i + 1; j < len(requirements); j++ {
+			if similarity(i, j) >= threshold {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+
+	// Collect the components in the order of their first requirement
+	var roots []int
+	members := make(map[int]Group)
+	for i, req := range requirements {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], req)
+	}
+
+	var groups []Group
+	for _, root := range roots {
+		component := members[root]
+		for start := 0; start < len(component); start += maxSize {
+			if chunk := component[start:min(start+maxSize, len(component))]; len(chunk) > 1 {
+				groups = append(groups, chunk)
+			}
+		}
+	}
+	return groups
+}
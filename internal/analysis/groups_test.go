@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// ids returns the IDs of the requirements in each group
+func ids(groups []Group) [][]string {
+	var result [][]string
+	for _, group := range groups {
+		var groupIDs []string
+		for _, req := range group {
+			groupIDs = append(groupIDs, req.ID)
+		}
+		result = append(result, groupIDs)
+	}
+	return result
+}
+
+func testRequirements() []types.Requirement {
+	return []types.Requirement{
+		{ID: "1", Children: []types.Requirement{
+			{ID: "1.1", Children: []types.Requirement{{ID: "1.1.1"}, {ID: "1.1.2"}}},
+			{ID: "1.2"},
+			{ID: "1.3", Children: []types.Requirement{{ID: "1.3.1"}}},
+		}},
+		{ID: "2", Children: []types.Requirement{{ID: "2.1"}}},
+		{ID: "3"},
+	}
+}
+
+func TestSubtreeGroups(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements []types.Requirement
+		maxSize      int
+		want         [][]string
+	}{
+		{
+			name:    "everything fits",
+			maxSize: 20,
+			want:    [][]string{{"1", "1.1", "1.1.1", "1.1.2", "1.2", "1.3", "1.3.1", "2", "2.1", "3"}},
+		},
+		{
+			name:    "large subtree is split over its children",
+			maxSize: 4,
+			want:    [][]string{{"1", "1.1", "1.1.1", "1.1.2"}, {"1.2", "1.3", "1.3.1"}, {"2", "2.1", "3"}},
+		},
+		{
+			name:    "single requirements are dropped",
+			maxSize: 2,
+			want:    [][]string{{"1", "1.1"}, {"1.1.1", "1.1.2"}, {"1.3", "1.3.1"}, {"2", "2.1"}},
+		},
+		{
+			name: "root is carried into the first group with room",
+			requirements: []types.Requirement{
+				{ID: "1", Children: []types.Requirement{
+					{ID: "1.1", Children: []types.Requirement{{ID: "1.1.1"}, {ID: "1.1.2"}, {ID: "1.1.3"}, {ID: "1.1.4"}}},
+					{ID: "1.2"},
+				}},
+			},
+			maxSize: 4,
+			want:    [][]string{{"1", "1.1", "1.1.1", "1.1.2"}, {"1.1.3", "1.1.4"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requirements := tt.requirements
+			if requirements == nil {
+				requirements = testRequirements()
+			}
+			if got := ids(SubtreeGroups(requirements, tt.maxSize)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubtreeGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarityGroups(t *testing.T) {
+	var requirements []*types.Requirement
+	for _, id := range []string{"1", "2", "3", "4", "5", "6"} {
+		requirements = append(requirements, &types.Requirement{ID: id})
+	}
+
+	// 1 resembles 3, 3 resembles 5 and 2 resembles 6; 4 resembles nothing
+	similar := map[[2]int]bool{{0, 2}: true, {2, 4}: true, {1, 5}: true}
+	similarity := func(i, j int) float64 {
+		if similar[[2]int{i, j}] {
+			return 0.9
+		}
+		return 0.1
+	}
+
+	got := ids(SimilarityGroups(requirements, similarity, 0.8, 10))
+	want := [][]string{{"1", "3", "5"}, {"2", "6"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SimilarityGroups() = %v, want %v", got, want)
+	}
+
+	got = ids(SimilarityGroups(requirements, similarity, 0.8, 2))
+	want = [][]string{{"1", "3"}, {"2", "6"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SimilarityGroups() with max size 2 = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,158 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultTTL is how long entries stay valid when no TTL is configured
+const DefaultTTL = 30 * 24 * time.Hour
+
+// Cache is an on-disk key/value store for AI responses. Each entry is a file whose
+// modification time records when it was written.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// Stats summarizes the contents of a cache
+type Stats struct {
+	Entries int       `json:"entries" yaml:"entries"`
+	Expired int       `json:"expired" yaml:"expired"`
+	Bytes   int64     `json:"bytes" yaml:"bytes"`
+	Oldest  time.Time `json:"oldest,omitzero" yaml:"oldest,omitempty"`
+	Newest  time.Time `json:"newest,omitzero" yaml:"newest,omitempty"`
+}
+
+// DefaultDir returns the cache directory: REQD_CACHE_DIR when set, otherwise reqd's
+// directory in the user cache directory, e.g. ~/.cache/reqd
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("REQD_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "reqd"), nil
+}
+
+// New returns a cache in dir, using DefaultTTL when ttl is zero
+func New(dir string, ttl time.Duration) *Cache {
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{Dir: dir, TTL: ttl}
+}
+
+// Key derives a cache key from its parts
+func Key(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write([]byte(part))
+		// Separate parts so that ("ab", "c") and ("a", "bc") differ
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// path returns the file of an entry, sharded by the first two characters of the key
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.Dir, "responses", key[:2], key)
+}
+
+// Get returns the value stored under key unless it is missing or expired
+func (c *Cache) Get(key string) (string, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil || c.expired(info) {
+		return "", false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Put stores a value under key
+func (c *Cache) Put(key, value string) error {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that concurrent readers never see a partial entry
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(value); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Clear removes every entry and returns how many were removed
+func (c *Cache) Clear() (int, error) {
+	stats, err := c.Stats()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(filepath.Join(c.Dir, "responses")); err != nil {
+		return 0, err
+	}
+	return stats.Entries, nil
+}
+
+// Stats counts the entries in the cache
+func (c *Cache) Stats() (Stats, error) {
+	var stats Stats
+	err := filepath.WalkDir(filepath.Join(c.Dir, "responses"), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		stats.Entries++
+		stats.Bytes += info.Size()
+		if c.expired(info) {
+			stats.Expired++
+		}
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// expired reports whether an entry is older than the TTL
+func (c *Cache) expired(info fs.FileInfo) bool {
+	return time.Since(info.ModTime()) > c.TTL
+}
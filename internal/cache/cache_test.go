@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	c := New(t.TempDir(), time.Hour)
+
+	key := Key("openai", "gpt-4o", "template", "input")
+	if Key("openai", "gpt-4o", "template", "input2") == key {
+		t.Fatalf("Key() returned the same key for different inputs")
+	}
+
+	if _, ok := c.Get(key); ok {
+		t.Errorf("Get() on empty cache found an entry")
+	}
+
+	if err := c.Put(key, `{"ok": true}`); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	value, ok := c.Get(key)
+	if !ok || value != `{"ok": true}` {
+		t.Errorf("Get() = %q, %v, want stored value", value, ok)
+	}
+
+	// Age the entry beyond the TTL
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(c.path(key), old, old); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(key); ok {
+		t.Errorf("Get() returned an expired entry")
+	}
+
+	stats, err := c.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.Entries != 1 || stats.Expired != 1 || stats.Bytes != int64(len(`{"ok": true}`)) {
+		t.Errorf("Stats() = %+v, want 1 expired entry", stats)
+	}
+
+	removed, err := c.Clear()
+	if err != nil || removed != 1 {
+		t.Errorf("Clear() = %d, %v, want 1 entry removed", removed, err)
+	}
+	if stats, _ := c.Stats(); stats.Entries != 0 {
+		t.Errorf("Stats() after Clear() = %+v, want empty", stats)
+	}
+}
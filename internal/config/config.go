@@ -0,0 +1,181 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFile is the name of the project configuration file, next to requirements.yaml
+const ProjectFile = ".reqd.yaml"
+
+// Config controls how reqd talks to the AI provider
+type Config struct {
+	Provider    string        `yaml:"provider,omitempty"`
+	Model       string        `yaml:"model,omitempty"`
+	BaseURL     string        `yaml:"base_url,omitempty"`
+	Temperature *float64      `yaml:"temperature,omitempty"`
+	Timeout     time.Duration `yaml:"timeout,omitempty"`
+	MaxTokens   int           `yaml:"max_tokens,omitempty"`
+	MaxRetries  *int          `yaml:"max_retries,omitempty"`
+	CacheTTL    time.Duration `yaml:"cache_ttl,omitempty"`
+	// EmbeddingModel is the model that turns requirement text into vectors for semantic search
+	EmbeddingModel string `yaml:"embedding_model,omitempty"`
+	// DuplicateThreshold is the similarity from 0 to 1 above which a new requirement is
+	// reported as a likely duplicate
+	DuplicateThreshold *float64 `yaml:"duplicate_threshold,omitempty"`
+}
+
+// Load returns the effective configuration. Settings are read from the user config file,
+// then the project's .reqd.yaml, then REQD_* environment variables, with later sources
+// overriding earlier ones.
+func Load() (*Config, error) {
+	cfg := &Config{}
+
+	if path, err := UserFile(); err == nil {
+		if err := cfg.mergeFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.mergeFile(ProjectFile); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.mergeEnv(); err != nil {
+		return nil, err
+	}
+
+	if cfg.DuplicateThreshold != nil && (*cfg.DuplicateThreshold < 0 || *cfg.DuplicateThreshold > 1) {
+		return nil, fmt.Errorf("duplicate_threshold must be between 0 and 1, got %v", *cfg.DuplicateThreshold)
+	}
+
+	return cfg, nil
+}
+
+// UserFile returns the path of the user configuration file, e.g. ~/.config/reqd/config.yaml
+func UserFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "reqd", "config.yaml"), nil
+}
+
+// mergeFile overrides settings with those found in a YAML file, ignoring a missing file
+func (c *Config) mergeFile(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var file Config
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	c.merge(&file)
+	return nil
+}
+
+// mergeEnv overrides settings with REQD_* environment variables
+func (c *Config) mergeEnv() error {
+	var env Config
+	env.Provider = os.Getenv("REQD_PROVIDER")
+	env.Model = os.Getenv("REQD_MODEL")
+	env.BaseURL = os.Getenv("REQD_BASE_URL")
+	env.EmbeddingModel = os.Getenv("REQD_EMBEDDING_MODEL")
+
+	if value := os.Getenv("REQD_TEMPERATURE"); value != "" {
+		temperature, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid REQD_TEMPERATURE '%s': %w", value, err)
+		}
+		env.Temperature = &temperature
+	}
+
+	if value := os.Getenv("REQD_TIMEOUT"); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid REQD_TIMEOUT '%s': %w", value, err)
+		}
+		env.Timeout = timeout
+	}
+
+	if value := os.Getenv("REQD_MAX_TOKENS"); value != "" {
+		maxTokens, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid REQD_MAX_TOKENS '%s': %w", value, err)
+		}
+		env.MaxTokens = maxTokens
+	}
+
+	if value := os.Getenv("REQD_CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid REQD_CACHE_TTL '%s': %w", value, err)
+		}
+		env.CacheTTL = ttl
+	}
+
+	if value := os.Getenv("REQD_MAX_RETRIES"); value != "" {
+		maxRetries, err := strconv.Atoi(value)
+		if err != nil || maxRetries < 0 {
+			return fmt.Errorf("invalid REQD_MAX_RETRIES '%s'", value)
+		}
+		env.MaxRetries = &maxRetries
+	}
+
+	if value := os.Getenv("REQD_DUPLICATE_THRESHOLD"); value != "" {
+		threshold, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid REQD_DUPLICATE_THRESHOLD '%s': %w", value, err)
+		}
+		env.DuplicateThreshold = &threshold
+	}
+
+	c.merge(&env)
+	return nil
+}
+
+// merge copies every setting that is set in other
+func (c *Config) merge(other *Config) {
+	if other.Provider != "" {
+		c.Provider = other.Provider
+	}
+	if other.Model != "" {
+		c.Model = other.Model
+	}
+	if other.BaseURL != "" {
+		c.BaseURL = other.BaseURL
+	}
+	if other.Temperature != nil {
+		c.Temperature = other.Temperature
+	}
+	if other.Timeout != 0 {
+		c.Timeout = other.Timeout
+	}
+	if other.MaxTokens != 0 {
+		c.MaxTokens = other.MaxTokens
+	}
+	if other.MaxRetries != nil {
+		c.MaxRetries = other.MaxRetries
+	}
+	if other.CacheTTL != 0 {
+		c.CacheTTL = other.CacheTTL
+	}
+	if other.EmbeddingModel != "" {
+		c.EmbeddingModel = other.EmbeddingModel
+	}
+	if other.DuplicateThreshold != nil {
+		c.DuplicateThreshold = other.DuplicateThreshold
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	project := `
+provider: ollama
+model: llama3.1
+base_url: http://gateway.internal
+temperature: 0.2
+timeout: 30s
+max_tokens: 512
+`
+	if err := os.WriteFile(ProjectFile, []byte(project), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Provider != "ollama" || cfg.Model != "llama3.1" || cfg.BaseURL != "http://gateway.internal" {
+		t.Errorf("Load() = %+v, want project file settings", cfg)
+	}
+	if cfg.Temperature == nil || *cfg.Temperature != 0.2 {
+		t.Errorf("Load() temperature = %v, want 0.2", cfg.Temperature)
+	}
+	if cfg.Timeout != 30*time.Second || cfg.MaxTokens != 512 {
+		t.Errorf("Load() timeout = %v, max tokens = %d, want 30s and 512", cfg.Timeout, cfg.MaxTokens)
+	}
+
+	t.Setenv("REQD_MODEL", "llama3.2")
+	t.Setenv("REQD_TEMPERATURE", "0")
+	t.Setenv("REQD_TIMEOUT", "2m")
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Provider != "ollama" || cfg.Model != "llama3.2" {
+		t.Errorf("Load() = %+v, want REQD_MODEL to override the model only", cfg)
+	}
+	if cfg.Temperature == nil || *cfg.Temperature != 0 {
+		t.Errorf("Load() temperature = %v, want 0 from REQD_TEMPERATURE", cfg.Temperature)
+	}
+	if cfg.Timeout != 2*time.Minute {
+		t.Errorf("Load() timeout = %v, want 2m", cfg.Timeout)
+	}
+
+	t.Setenv("REQD_MAX_TOKENS", "lots")
+	if _, err := Load(); err == nil {
+		t.Errorf("Load() with invalid REQD_MAX_TOKENS succeeded, want error")
+	}
+}
+
+func TestLoad_DuplicateThreshold(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	if err := os.WriteFile(ProjectFile, []byte("duplicate_threshold: 0.8\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DuplicateThreshold == nil || *cfg.DuplicateThreshold != 0.8 {
+		t.Errorf("Load() duplicate threshold = %v, want 0.8 from the project file", cfg.DuplicateThreshold)
+	}
+
+	t.Setenv("REQD_DUPLICATE_THRESHOLD", "0.9")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DuplicateThreshold == nil || *cfg.DuplicateThreshold != 0.9 {
+		t.Errorf("Load() duplicate threshold = %v, want 0.9 from REQD_DUPLICATE_THRESHOLD", cfg.DuplicateThreshold)
+	}
+
+	t.Setenv("REQD_DUPLICATE_THRESHOLD", "1.5")
+	if _, err := Load(); err == nil {
+		t.Errorf("Load() with REQD_DUPLICATE_THRESHOLD out of range succeeded, want error")
+	}
+}
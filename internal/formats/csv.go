@@ -0,0 +1,305 @@
+package formats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// CSV columns in export order. Cells with several values separate tags with commas and
+// acceptance criteria and links with line breaks.
+const (
+	ColumnID         = "id"
+	ColumnUID        = "uid"
+	ColumnParent     = "parent"
+	ColumnDepth      = "depth"
+	ColumnText       = "text"
+	ColumnStatus     = "status"
+	ColumnPriority   = "priority"
+	ColumnTags       = "tags"
+	ColumnRationale  = "rationale"
+	ColumnSource     = "source"
+	ColumnOwner      = "owner"
+	ColumnAcceptance = "acceptance"
+	ColumnLinks      = "links"
+	ColumnCreated    = "created"
+	ColumnUpdated    = "updated"
+)
+
+// CSVColumns lists every exported column
+var CSVColumns = []string{
+	ColumnID, ColumnUID, ColumnParent, ColumnDepth, ColumnText, ColumnStatus, ColumnPriority, ColumnTags,
+	ColumnRationale, ColumnSource, ColumnOwner, ColumnAcceptance, ColumnLinks, ColumnCreated, ColumnUpdated,
+}
+
+// WriteCSV renders the project as CSV with a header and one row per requirement in tree order
+func WriteCSV(w io.Writer, project *types.Project) error {
+	writer := csv.NewWriter(w)
+	writer.Write(CSVColumns)
+
+	var write func(requirements []types.Requirement, parentID string, depth int)
+	write = func(requirements []types.Requirement, parentID string, depth int) {
+		for i := range requirements {
+			req := &requirements[i]
+
+			var links []string
+			for _, link := range req.Links {
+				links = append(links, link.Type+" "+link.Target)
+			}
+
+			writer.Write([]string{
+				req.ID,
+				req.UID,
+				parentID,
+				strconv.Itoa(depth),
+				req.Text,
+				req.CurrentStatus(),
+				req.Priority,
+				strings.Join(req.Tags, ", "),
+				req.Rationale,
+				req.Source,
+				req.Owner,
+				strings.Join(req.Acceptance, "\n"),
+				strings.Join(links, "\n"),
+				csvTime(req.Created),
+				csvTime(req.Updated),
+			})
+			write(req.Children, req.ID, depth+1)
+		}
+	}
+	write(project.Requirements, "", 0)
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvTime formats a timestamp, leaving unset timestamps empty
+func csvTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseCSVTime parses a time written by csvTime, returning the zero time when it cannot
+func parseCSVTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// CSVRow is one row of an imported CSV file
+type CSVRow struct {
+	// Line is the line number of the row for error messages
+	Line int
+	// ID, UID and Parent identify the row and its parent; any of them may be empty
+	ID     string
+	UID    string
+	Parent string
+	// Requirement holds the values of the requirement columns present in the file
+	Requirement types.Requirement
+
+	columns map[string]bool
+}
+
+// ReadCSV reads rows written by WriteCSV, possibly edited in a spreadsheet. Columns are matched by
+// header name in any order and may be left out, except for the text column. The depth column is
+// informational and ignored. Created and updated times are read when they are in RFC 3339 format,
+// and left unset when a spreadsheet reformatted them.
+func ReadCSV(r io.Reader) ([]CSVRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	index := make(map[string]int)
+	columns := make(map[string]bool)
+	for i, name := range header {
+		// Spreadsheet programs may start the file with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if slices.Contains(CSVColumns, name) {
+			index[name] = i
+			columns[name] = true
+		}
+	}
+	if !columns[ColumnText] {
+		return nil, fmt.Errorf("CSV has no '%s' column", ColumnText)
+	}
+
+	var rows []CSVRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		cell := func(column string) string {
+			if i, ok := index[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		// Skip rows left empty by spreadsheet editors
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		row := CSVRow{
+			Line:    line,
+			ID:      cell(ColumnID),
+			UID:     strings.ToUpper(cell(ColumnUID)),
+			Parent:  cell(ColumnParent),
+			columns: columns,
+		}
+
+		req := &row.Requirement
+		req.Text = cell(ColumnText)
+		req.Status = strings.ToLower(cell(ColumnStatus))
+		req.Rationale = cell(ColumnRationale)
+		req.Source = cell(ColumnSource)
+		req.Owner = cell(ColumnOwner)
+		req.Created = parseCSVTime(cell(ColumnCreated))
+		req.Updated = parseCSVTime(cell(ColumnUpdated))
+
+		if req.Text == "" {
+			return nil, fmt.Errorf("line %d: requirement text cannot be empty", line)
+		}
+		if priority := cell(ColumnPriority); priority != "" {
+			if req.Priority, err = types.ParsePriority(priority); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		for _, tag := range strings.Split(cell(ColumnTags), ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				req.AddTag(tag)
+			}
+		}
+		for _, criterion := range strings.Split(cell(ColumnAcceptance), "\n") {
+			if criterion = strings.TrimSpace(criterion); criterion != "" {
+				req.Acceptance = append(req.Acceptance, criterion)
+			}
+		}
+		for _, link := range strings.Split(cell(ColumnLinks), "\n") {
+			fields := strings.Fields(link)
+			if len(fields) == 0 {
+				continue
+			}
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: invalid link '%s' (expected '<type> <uid>')", line, strings.TrimSpace(link))
+			}
+			linkType, err := types.ParseLinkType(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			req.AddLink(linkType, strings.ToUpper(fields[1]))
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// HasColumn reports whether the file the row was read from has the column
+func (row *CSVRow) HasColumn(column string) bool {
+	return row.columns[column]
+}
+
+// Apply copies the values of the row's columns to req and returns the names of the columns
+// that changed. Columns missing from the file are left alone, and so are the created and updated
+// times. An empty status equals "draft".
+func (row *CSVRow) Apply(req *types.Requirement) []string {
+	var changed []string
+	set := func(column string, differs bool, apply func()) {
+		if row.HasColumn(column) && differs {
+			apply()
+			changed = append(changed, column)
+		}
+	}
+
+	values := &row.Requirement
+	set(ColumnText, values.Text != req.Text, func() { req.Text = values.Text })
+	set(ColumnStatus, values.CurrentStatus() != req.CurrentStatus(), func() { req.Status = values.Status })
+	set(ColumnPriority, values.Priority != req.Priority, func() { req.Priority = values.Priority })
+	set(ColumnTags, !slices.Equal(values.Tags, req.Tags), func() { req.Tags = values.Tags })
+	set(ColumnRationale, values.Rationale != req.Rationale, func() { req.Rationale = values.Rationale })
+	set(ColumnSource, values.Source != req.Source, func() { req.Source = values.Source })
+	set(ColumnOwner, values.Owner != req.Owner, func() { req.Owner = values.Owner })
+	set(ColumnAcceptance, !slices.Equal(values.Acceptance, req.Acceptance), func() { req.Acceptance = values.Acceptance })
+	set(ColumnLinks, !slices.Equal(values.Links, req.Links), func() { req.Links = values.Links })
+
+	return changed
+}
+
+// CSVTree builds requirement trees from rows, nesting each row under the earlier or later row its
+// parent column names by ID or UID. Rows without a known parent become top-level requirements.
+// Positional IDs are assigned in row order.
+func CSVTree(rows []CSVRow) []types.Requirement {
+	keys := make(map[string]int)
+	for i, row := range rows {
+		for _, key := range []string{row.ID, row.UID} {
+			if _, exists := keys[strings.ToUpper(key)]; key != "" && !exists {
+				keys[strings.ToUpper(key)] = i
+			}
+		}
+	}
+
+	children := make(map[int][]int)
+	var roots []int
+	for i, row := range rows {
+		parent, ok := keys[strings.ToUpper(row.Parent)]
+		if row.Parent == "" || !ok || parent == i {
+			roots = append(roots, i)
+		} else {
+			children[parent] = append(children[parent], i)
+		}
+	}
+
+	visited := make(map[int]bool)
+	var build func(indexes []int) []types.Requirement
+	build = func(indexes []int) []types.Requirement {
+		var requirements []types.Requirement
+		for _, i := range indexes {
+			if visited[i] {
+				continue
+			}
+			visited[i] = true
+
+			req := rows[i].Requirement
+			req.UID = rows[i].UID
+			req.Children = build(children[i])
+			requirements = append(requirements, req)
+		}
+		return requirements
+	}
+	requirements := build(roots)
+
+	// Rows in a parent cycle are not reachable from a root; keep them at the top level
+	for i := range rows {
+		if !visited[i] {
+			requirements = append(requirements, build([]int{i})...)
+		}
+	}
+
+	numberRequirements(requirements, "")
+	return requirements
+}
@@ -0,0 +1,121 @@
+package formats
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+func TestCSV_RoundTrip(t *testing.T) {
+	original := testProject()
+
+	var out bytes.Buffer
+	if err := WriteCSV(&out, original); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	header := "id,uid,parent,depth,text,status,priority,tags,rationale,source,owner,acceptance,links,created,updated\n"
+	if !strings.HasPrefix(out.String(), header) {
+		t.Errorf("WriteCSV() header = %q, want %q", strings.SplitN(out.String(), "\n", 2)[0], header)
+	}
+	if !strings.Contains(out.String(), "\n1.1,REQ-0002,1,1,") {
+		t.Errorf("WriteCSV() is missing the row of 1.1 with its parent and depth:\n%s", out.String())
+	}
+
+	rows, err := ReadCSV(&out)
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("ReadCSV() returned %d rows, want 3", len(rows))
+	}
+
+	// Re-applying the exported rows to the original requirements changes nothing
+	for _, row := range rows {
+		req := original.FindRequirement(row.UID)
+		if changed := row.Apply(req); len(changed) != 0 {
+			t.Errorf("Apply() of unchanged row %s changed %v", row.ID, changed)
+		}
+	}
+
+	tree := CSVTree(rows)
+	if len(tree) != 2 || len(tree[0].Children) != 1 || tree[0].Children[0].UID != "REQ-0002" || tree[0].Children[0].ID != "1.1" {
+		t.Errorf("CSVTree() = %+v, want the original hierarchy", tree)
+	}
+	if !reflect.DeepEqual(tree[0].Links, original.Requirements[0].Links) {
+		t.Errorf("CSVTree() links = %v, want %v", tree[0].Links, original.Requirements[0].Links)
+	}
+}
+
+func TestReadCSV_Times(t *testing.T) {
+	input := "text,created,updated\n" +
+		"The system MUST log in users,2025-01-01T09:00:00Z,2025-02-01T10:30:00Z\n" +
+		"The system MUST log out users,01/01/2025 09:00,\n"
+
+	rows, err := ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 2, 1, 10, 30, 0, 0, time.UTC)
+	if got := rows[0].Requirement; !got.Created.Equal(created) || !got.Updated.Equal(updated) {
+		t.Errorf("ReadCSV() times = %v, %v, want %v, %v", got.Created, got.Updated, created, updated)
+	}
+	// A date reformatted by a spreadsheet is left unset
+	if got := rows[1].Requirement; !got.Created.IsZero() || !got.Updated.IsZero() {
+		t.Errorf("ReadCSV() times = %v, %v, want zero", got.Created, got.Updated)
+	}
+}
+
+func TestCSVRow_Apply(t *testing.T) {
+	input := "\ufeffID,Text,Priority,Tags,Acceptance\n" +
+		"1,Users MUST be able to log in with SSO,Should,\"security, SSO\",\"Given a user\nWhen they log in\"\n"
+
+	rows, err := ReadCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+
+	req := testProject().Requirements[0]
+	changed := rows[0].Apply(&req)
+
+	expected := []string{ColumnText, ColumnPriority, ColumnTags, ColumnAcceptance}
+	if !reflect.DeepEqual(changed, expected) {
+		t.Errorf("Apply() changed = %v, want %v", changed, expected)
+	}
+	if req.Priority != types.PriorityShould || !reflect.DeepEqual(req.Tags, []string{"security", "sso"}) {
+		t.Errorf("Apply() priority, tags = %q, %v", req.Priority, req.Tags)
+	}
+	if len(req.Acceptance) != 2 {
+		t.Errorf("Apply() acceptance = %v, want 2 criteria", req.Acceptance)
+	}
+	// Columns missing from the file are left alone
+	if req.Status != types.StatusApproved || req.Rationale == "" || len(req.Links) != 1 {
+		t.Errorf("Apply() changed columns that are not in the file: %+v", req)
+	}
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "no text column", input: "id,uid\n1,REQ-0001\n"},
+		{name: "empty text", input: "id,text\n1,\n"},
+		{name: "unknown priority", input: "text,priority\nA,urgent\n"},
+		{name: "invalid link", input: "text,links\nA,depends-on\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadCSV(strings.NewReader(tt.input)); err == nil {
+				t.Errorf("ReadCSV(%q) should fail", tt.input)
+			}
+		})
+	}
+}
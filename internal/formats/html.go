@@ -0,0 +1,103 @@
+package formats
+
+import (
+	"embed"
+	"encoding/json"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+//go:embed templates/report.html.tmpl
+var templates embed.FS
+
+var reportTemplate = template.Must(template.ParseFS(templates, "templates/report.html.tmpl"))
+
+// htmlReport is the data rendered by the HTML report template
+type htmlReport struct {
+	Name         string
+	Statuses     []string
+	Tags         []string
+	Requirements []htmlRequirement
+}
+
+// htmlRequirement is a requirement prepared for the HTML report template
+type htmlRequirement struct {
+	*types.Requirement
+	Anchor string
+	Status string
+	Search string
+	// TagList holds the tags as a JSON array, which keeps multi-word tags intact for the tag filter
+	TagList  string
+	Links    []htmlLink
+	Children []htmlRequirement
+}
+
+// htmlLink is an outbound link resolved to the requirement it points at
+type htmlLink struct {
+	Label  string
+	Target string
+	Anchor string
+}
+
+// WriteHTML renders the project as a self-contained HTML report with a collapsible requirement
+// tree, a search box and status and tag filters
+func WriteHTML(w io.Writer, project *types.Project) error {
+	report := htmlReport{
+		Name:         project.Name,
+		Statuses:     project.Statuses(),
+		Requirements: htmlRequirements(project, project.Requirements),
+	}
+
+	project.Walk(func(req *types.Requirement) {
+		for _, tag := range req.Tags {
+			if !slices.Contains(report.Tags, tag) {
+				report.Tags = append(report.Tags, tag)
+			}
+		}
+	})
+	slices.Sort(report.Tags)
+
+	return reportTemplate.Execute(w, report)
+}
+
+// htmlRequirements prepares a list of requirements and their descendants for the template
+func htmlRequirements(project *types.Project, requirements []types.Requirement) []htmlRequirement {
+	var result []htmlRequirement
+	for i := range requirements {
+		req := &requirements[i]
+
+		var links []htmlLink
+		for _, link := range req.Links {
+			resolved := htmlLink{Label: linkLabel(link.Type), Target: link.Target}
+			if linked := project.FindRequirement(link.Target); linked != nil {
+				resolved.Target = linked.ID
+				resolved.Anchor = Anchor(linked)
+			}
+			links = append(links, resolved)
+		}
+
+		result = append(result, htmlRequirement{
+			Requirement: req,
+			Anchor:      Anchor(req),
+			Status:      req.CurrentStatus(),
+			Search:      strings.ToLower(strings.Join([]string{req.ID, req.UID, req.Text}, " ")),
+			TagList:     tagList(req.Tags),
+			Links:       links,
+			Children:    htmlRequirements(project, req.Children),
+		})
+	}
+	return result
+}
+
+// tagList encodes tags as a JSON array for the data-tags attribute
+func tagList(tags []string) string {
+	if tags == nil {
+		tags = []string{}
+	}
+	data, _ := json.Marshal(tags)
+	return string(data)
+}
@@ -0,0 +1,36 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteHTML(t *testing.T) {
+	project := testProject()
+	project.Requirements[1].Text = "Scripts such as <script>alert(1)</script> MUST NOT run"
+	project.Requirements[1].Tags = []string{"data privacy"}
+
+	var out strings.Builder
+	if err := WriteHTML(&out, project); err != nil {
+		t.Fatalf("WriteHTML() error = %v", err)
+	}
+	doc := out.String()
+
+	for _, expected := range []string{
+		"<title>Portal</title>",
+		`<li id="req-1" data-search="1 req-0001 users must be able to log in" data-status="approved" data-tags="[&#34;security&#34;]">`,
+		`data-tags="[&#34;data privacy&#34;]"`,
+		`<option value="data privacy">data privacy</option>`,
+		`<details class="node" open>`,
+		`<li id="req-1-1"`,
+		`<option value="security">security</option>`,
+		`<option value="verified">verified</option>`,
+		`<dt>Depends on</dt><dd><a href="#req-2">2</a></dd>`,
+		"<li>Given an invalid address, when submitted, then an error is shown</li>",
+		"&lt;script&gt;alert(1)&lt;/script&gt;",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("WriteHTML() output is missing %q", expected)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// Markdown layouts for the requirement tree
+const (
+	MarkdownHeadings = "headings"
+	MarkdownList     = "list"
+)
+
+// maxHeadingLevel is the deepest heading Markdown supports
+const maxHeadingLevel = 6
+
+// MarkdownOptions controls how a project is rendered as Markdown
+type MarkdownOptions struct {
+	// Layout is MarkdownHeadings (one heading per requirement) or MarkdownList (a nested list)
+	Layout string
+	// TOC adds a table of contents linking to every requirement
+	TOC bool
+}
+
+// Anchor returns the HTML anchor of a requirement, derived from its ID so that links such as
+// #req-1-2 keep pointing at the same position in every export
+func Anchor(req *types.Requirement) string {
+	return "req-" + strings.ReplaceAll(req.ID, ".", "-")
+}
+
+// WriteMarkdown renders the project as a Markdown document with the project name as its title
+func WriteMarkdown(w io.Writer, project *types.Project, opts MarkdownOptions) error {
+	if opts.Layout == "" {
+		opts.Layout = MarkdownHeadings
+	}
+	if opts.Layout != MarkdownHeadings && opts.Layout != MarkdownList {
+		return fmt.Errorf("unknown Markdown layout '%s' (supported: %s, %s)", opts.Layout, MarkdownHeadings, MarkdownList)
+	}
+
+	m := &markdownWriter{w: bufio.NewWriter(w), project: project}
+	m.printf("# %s\n", project.Name)
+
+	if opts.TOC {
+		m.printf("\n## Contents\n\n")
+		m.contents(project.Requirements, 0)
+	}
+
+	if opts.Layout == MarkdownList {
+		m.printf("\n")
+	}
+	for i := range project.Requirements {
+		if opts.Layout == MarkdownList {
+			m.listItem(&project.Requirements[i], 0)
+		} else {
+			m.section(&project.Requirements[i], 0)
+		}
+	}
+
+	return m.w.Flush()
+}
+
+// markdownWriter renders requirements; write errors are kept by the bufio.Writer and
+// reported by Flush
+type markdownWriter struct {
+	w       *bufio.Writer
+	project *types.Project
+}
+
+func (m *markdownWriter) printf(format string, args ...any) {
+	fmt.Fprintf(m.w, format, args...)
+}
+
+// contents renders the table of contents as a nested list of links
+func (m *markdownWriter) contents(requirements []types.Requirement, depth int) {
+	for i := range requirements {
+		req := &requirements[i]
+		m.printf("%s- [%s %s](#%s)\n", strings.Repeat("  ", depth), req.ID, escapeLinkText(req.Text), Anchor(req))
+		m.contents(req.Children, depth+1)
+	}
+}
+
+// section renders a requirement as a heading followed by its details. Top-level requirements
+// are level 2 headings below the title; levels deeper than 6 stay at 6.
+func (m *markdownWriter) section(req *types.Requirement, depth int) {
+	level := min(depth+2, maxHeadingLevel)
+	m.printf("\n%s <a id=\"%s\"></a>%s %s\n\n", strings.Repeat("#", level), Anchor(req), req.ID, req.Text)
+	m.details(req, "")
+
+	for i := range req.Children {
+		m.section(&req.Children[i], depth+1)
+	}
+}
+
+// listItem renders a requirement as a list item with its details and children nested below
+func (m *markdownWriter) listItem(req *types.Requirement, depth int) {
+	indent := strings.Repeat("  ", depth)
+	m.printf("%s- <a id=\"%s\"></a>**%s** %s\n", indent, Anchor(req), req.ID, req.Text)
+	m.details(req, indent+"  ")
+
+	for i := range req.Children {
+		m.listItem(&req.Children[i], depth+1)
+	}
+}
+
+// details renders the metadata, links and acceptance criteria of a requirement as a list
+func (m *markdownWriter) details(req *types.Requirement, indent string) {
+	field := func(name, value string) {
+		if value != "" {
+			m.printf("%s- **%s:** %s\n", indent, name, value)
+		}
+	}
+
+	field("UID", req.UID)
+	field("Status", req.CurrentStatus())
+	field("Priority", req.Priority)
+	field("Tags", strings.Join(req.Tags, ", "))
+	field("Rationale", req.Rationale)
+	field("Source", req.Source)
+	field("Owner", req.Owner)
+
+	for _, link := range req.Links {
+		target := link.Target
+		if linked := m.project.FindRequirement(link.Target); linked != nil {
+			target = fmt.Sprintf("[%s](#%s)", linked.ID, Anchor(linked))
+		}
+		field(linkLabel(link.Type), target)
+	}
+
+	if len(req.Acceptance) > 0 {
+		m.printf("%s- **Acceptance criteria:**\n", indent)
+		for i, criterion := range req.Acceptance {
+			m.printf("%s  %d. %s\n", indent, i+1, criterion)
+		}
+	}
+}
+
+// linkLabel turns a link type such as "depends-on" into "Depends on", or "Link" when the type is empty
+func linkLabel(linkType string) string {
+	label := strings.TrimSpace(strings.ReplaceAll(linkType, "-", " "))
+	if label == "" {
+		return "Link"
+	}
+	first, size := utf8.DecodeRuneInString(label)
+	return string(unicode.ToUpper(first)) + label[size:]
+}
+
+// escapeLinkText escapes the characters that would end Markdown link text early
+func escapeLinkText(text string) string {
+	return strings.NewReplacer(`[`, `\[`, `]`, `\]`).Replace(text)
+}
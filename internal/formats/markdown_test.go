@@ -0,0 +1,104 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+func testProject() *types.Project {
+	return &types.Project{
+		Name: "Portal",
+		Requirements: []types.Requirement{
+			{
+				ID:        "1",
+				UID:       "REQ-0001",
+				Text:      "Users MUST be able to log in",
+				Status:    types.StatusApproved,
+				Priority:  types.PriorityMust,
+				Tags:      []string{"security"},
+				Rationale: "Accounts hold private data",
+				Links:     []types.Link{{Type: types.LinkDependsOn, Target: "REQ-0003"}},
+				Children: []types.Requirement{
+					{
+						ID:         "1.1",
+						UID:        "REQ-0002",
+						Text:       "The login form MUST validate [email] addresses",
+						Acceptance: []string{"Given an invalid address, when submitted, then an error is shown"},
+					},
+				},
+			},
+			{ID: "2", UID: "REQ-0003", Text: "Users SHOULD be able to reset their password"},
+		},
+	}
+}
+
+func TestWriteMarkdown_Headings(t *testing.T) {
+	var out strings.Builder
+	if err := WriteMarkdown(&out, testProject(), MarkdownOptions{TOC: true}); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	doc := out.String()
+
+	for _, expected := range []string{
+		"# Portal\n",
+		"## Contents\n\n- [1 Users MUST be able to log in](#req-1)\n  - [1.1 The login form MUST validate \\[email\\] addresses](#req-1-1)\n",
+		"\n## <a id=\"req-1\"></a>1 Users MUST be able to log in\n",
+		"- **UID:** REQ-0001\n- **Status:** approved\n- **Priority:** must\n- **Tags:** security\n- **Rationale:** Accounts hold private data\n",
+		"- **Depends on:** [2](#req-2)\n",
+		"\n### <a id=\"req-1-1\"></a>1.1 The login form",
+		"- **Status:** draft\n",
+		"- **Acceptance criteria:**\n  1. Given an invalid address",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("WriteMarkdown() output is missing %q:\n%s", expected, doc)
+		}
+	}
+}
+
+func TestWriteMarkdown_List(t *testing.T) {
+	var out strings.Builder
+	if err := WriteMarkdown(&out, testProject(), MarkdownOptions{Layout: MarkdownList}); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	doc := out.String()
+
+	if strings.Contains(doc, "## Contents") {
+		t.Errorf("WriteMarkdown() wrote a table of contents without TOC:\n%s", doc)
+	}
+	for _, expected := range []string{
+		"- <a id=\"req-1\"></a>**1** Users MUST be able to log in\n  - **UID:** REQ-0001\n",
+		"  - <a id=\"req-1-1\"></a>**1.1** The login form",
+		"    - **Acceptance criteria:**\n      1. Given",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("WriteMarkdown() output is missing %q:\n%s", expected, doc)
+		}
+	}
+}
+
+func TestWriteMarkdown_UnknownLayout(t *testing.T) {
+	if err := WriteMarkdown(&strings.Builder{}, testProject(), MarkdownOptions{Layout: "table"}); err == nil {
+		t.Error("WriteMarkdown() with an unknown layout should fail")
+	}
+}
+
+func Test_linkLabel(t *testing.T) {
+	tests := []struct {
+		linkType string
+		want     string
+	}{
+		{"depends-on", "Depends on"},
+		{"refines", "Refines"},
+		{"élargit", "Élargit"},
+		{"", "Link"},
+		{"-", "Link"},
+	}
+
+	for _, tt := range tests {
+		if got := linkLabel(tt.linkType); got != tt.want {
+			t.Errorf("linkLabel(%q) = %q, want %q", tt.linkType, got, tt.want)
+		}
+	}
+}
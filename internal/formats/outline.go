@@ -0,0 +1,97 @@
+package formats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// tabWidth is the number of spaces a tab counts for when measuring indentation
+const tabWidth = 4
+
+// OutlineItem is one entry of an imported outline together with its nested entries
+type OutlineItem struct {
+	Text     string
+	Line     int
+	Children []OutlineItem
+}
+
+var (
+	// bulletPattern matches Markdown list markers: "-", "*", "+", "1." and "1)"
+	bulletPattern = regexp.MustCompile(`^([-*+]|\d+[.)])\s+`)
+	// checkboxPattern matches a task list checkbox following the list marker
+	checkboxPattern = regexp.MustCompile(`^\[[ xX]\]\s+`)
+	// rulePattern matches Markdown horizontal rules such as "---" and "***"
+	rulePattern = regexp.MustCompile(`^([-*_])(\s*([-*_]))*$`)
+)
+
+// ParseOutline reads plain lines or Markdown bullet lists and returns them as a tree. Deeper
+// indentation nests a line under the closest preceding line with less indentation. Blank lines,
+// headings, horizontal rules and fenced code blocks are skipped.
+func ParseOutline(r io.Reader) ([]OutlineItem, error) {
+	type frame struct {
+		indent int
+		item   *OutlineItem
+	}
+
+	var root OutlineItem
+	stack := []frame{{indent: -1, item: &root}}
+	inFence := false
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(line, " \t")
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || trimmed == "" || strings.HasPrefix(trimmed, "#") || isRule(trimmed) {
+			continue
+		}
+
+		text := bulletPattern.ReplaceAllString(trimmed, "")
+		text = strings.TrimSpace(checkboxPattern.ReplaceAllString(text, ""))
+		if text == "" {
+			continue
+		}
+
+		indent := indentation(line)
+		for len(stack) > 1 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		parent := stack[len(stack)-1].item
+		parent.Children = append(parent.Children, OutlineItem{Text: text, Line: lineNumber})
+		stack = append(stack, frame{indent: indent, item: &parent.Children[len(parent.Children)-1]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read outline: %w", err)
+	}
+
+	return root.Children, nil
+}
+
+// indentation returns the width of the leading whitespace of a line
+func indentation(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += tabWidth
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// isRule reports whether a trimmed line is a Markdown horizontal rule
+func isRule(trimmed string) bool {
+	return len(strings.ReplaceAll(trimmed, " ", "")) >= 3 && rulePattern.MatchString(trimmed)
+}
@@ -0,0 +1,86 @@
+package formats
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// outlineTexts flattens an outline into "depth:text" entries for comparison
+func outlineTexts(items []OutlineItem, depth int) []string {
+	var texts []string
+	for _, item := range items {
+		texts = append(texts, strings.Repeat(">", depth)+item.Text)
+		texts = append(texts, outlineTexts(item.Children, depth+1)...)
+	}
+	return texts
+}
+
+func TestParseOutline(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{
+			name:     "plain lines",
+			input:    "First\nSecond\n\nThird\n",
+			expected: []string{"First", "Second", "Third"},
+		},
+		{
+			name: "nested bullets",
+			input: `- Authentication
+  - Login
+    - Email format
+  - Logout
+- Reporting
+`,
+			expected: []string{"Authentication", ">Login", ">>Email format", ">Logout", "Reporting"},
+		},
+		{
+			name:     "numbered lists, checkboxes and tabs",
+			input:    "1. Search\n\t2) By tag\n\t* [ ] By status\n",
+			expected: []string{"Search", ">By tag", ">By status"},
+		},
+		{
+			name: "headings, rules and code blocks are skipped",
+			input: `# Product requirements
+
+- Export
+---
+` + "```" + `
+- not a requirement
+` + "```" + `
+* Import
+`,
+			expected: []string{"Export", "Import"},
+		},
+		{
+			name:     "dedent to an intermediate level",
+			input:    "- A\n    - B\n  - C\n",
+			expected: []string{"A", ">B", ">C"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := ParseOutline(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseOutline() error = %v", err)
+			}
+			if texts := outlineTexts(items, 0); !reflect.DeepEqual(texts, tt.expected) {
+				t.Errorf("ParseOutline() = %q, want %q", texts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseOutline_LineNumbers(t *testing.T) {
+	items, err := ParseOutline(strings.NewReader("# Title\n\n- A\n  - B\n"))
+	if err != nil {
+		t.Fatalf("ParseOutline() error = %v", err)
+	}
+	if items[0].Line != 3 || items[0].Children[0].Line != 4 {
+		t.Errorf("line numbers = %d, %d, want 3, 4", items[0].Line, items[0].Children[0].Line)
+	}
+}
@@ -0,0 +1,442 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// reqifNamespace is the XML namespace of ReqIF 1.0 and later
+const reqifNamespace = "http://www.omg.org/spec/ReqIF/20110401/reqif.xsd"
+
+// Identifiers of the types declared in exported ReqIF documents
+const (
+	reqifStringType        = "reqd-string"
+	reqifRequirementType   = "reqd-requirement"
+	reqifSpecificationType = "reqd-specification"
+	reqifRelationTypeIDs   = "reqd-relation-"
+)
+
+// ReqIF attribute names; ReqIF.Text and ReqIF.ForeignID are standard, the others are reqd's own
+const (
+	reqifText       = "ReqIF.Text"
+	reqifForeignID  = "ReqIF.ForeignID"
+	reqifID         = "reqd.ID"
+	reqifStatus     = "reqd.Status"
+	reqifPriority   = "reqd.Priority"
+	reqifTags       = "reqd.Tags"
+	reqifRationale  = "reqd.Rationale"
+	reqifSource     = "reqd.Source"
+	reqifOwner      = "reqd.Owner"
+	reqifAcceptance = "reqd.Acceptance"
+	reqifCreated    = "reqd.Created"
+)
+
+// reqifAttributes lists the exported attributes in declaration order
+var reqifAttributes = []string{
+	reqifForeignID, reqifID, reqifText, reqifStatus, reqifPriority, reqifTags,
+	reqifRationale, reqifSource, reqifOwner, reqifAcceptance, reqifCreated,
+}
+
+// reqifTextNames are the attribute names other tools use for the requirement text
+var reqifTextNames = []string{reqifText, "Object Text", "Text", "Description"}
+
+type reqifDocument struct {
+	XMLName xml.Name     `xml:"http://www.omg.org/spec/ReqIF/20110401/reqif.xsd REQ-IF"`
+	Header  reqifHeader  `xml:"THE-HEADER>REQ-IF-HEADER"`
+	Content reqifContent `xml:"CORE-CONTENT>REQ-IF-CONTENT"`
+}
+
+type reqifHeader struct {
+	Identifier   string `xml:"IDENTIFIER,attr"`
+	CreationTime string `xml:"CREATION-TIME"`
+	ReqIFToolID  string `xml:"REQ-IF-TOOL-ID"`
+	ReqIFVersion string `xml:"REQ-IF-VERSION"`
+	SourceToolID string `xml:"SOURCE-TOOL-ID"`
+	Title        string `xml:"TITLE"`
+}
+
+type reqifContent struct {
+	Datatypes          []reqifIdentifiable       `xml:"DATATYPES>DATATYPE-DEFINITION-STRING"`
+	SpecObjectTypes    []reqifSpecObjectType     `xml:"SPEC-TYPES>SPEC-OBJECT-TYPE"`
+	SpecRelationTypes  []reqifIdentifiable       `xml:"SPEC-TYPES>SPEC-RELATION-TYPE"`
+	SpecificationTypes []reqifIdentifiable       `xml:"SPEC-TYPES>SPECIFICATION-TYPE"`
+	SpecObjects        []reqifSpecObject         `xml:"SPEC-OBJECTS>SPEC-OBJECT"`
+	SpecRelations      []reqifSpecRelation       `xml:"SPEC-RELATIONS>SPEC-RELATION"`
+	Specifications     []reqifSpecificationEntry `xml:"SPECIFICATIONS>SPECIFICATION"`
+}
+
+// reqifIdentifiable is an element that only carries the common ReqIF attributes
+type reqifIdentifiable struct {
+	Identifier string `xml:"IDENTIFIER,attr"`
+	LongName   string `xml:"LONG-NAME,attr,omitempty"`
+	LastChange string `xml:"LAST-CHANGE,attr"`
+	MaxLength  string `xml:"MAX-LENGTH,attr,omitempty"`
+}
+
+type reqifSpecObjectType struct {
+	reqifIdentifiable
+	StringAttributes []reqifAttributeDefinition `xml:"SPEC-ATTRIBUTES>ATTRIBUTE-DEFINITION-STRING"`
+	XHTMLAttributes  []reqifAttributeDefinition `xml:"SPEC-ATTRIBUTES>ATTRIBUTE-DEFINITION-XHTML"`
+}
+
+type reqifAttributeDefinition struct {
+	reqifIdentifiable
+	Type string `xml:"TYPE>DATATYPE-DEFINITION-STRING-REF,omitempty"`
+}
+
+type reqifSpecObject struct {
+	Identifier   string             `xml:"IDENTIFIER,attr"`
+	LastChange   string             `xml:"LAST-CHANGE,attr"`
+	StringValues []reqifStringValue `xml:"VALUES>ATTRIBUTE-VALUE-STRING"`
+	XHTMLValues  []reqifXHTMLValue  `xml:"VALUES>ATTRIBUTE-VALUE-XHTML"`
+	Type         string             `xml:"TYPE>SPEC-OBJECT-TYPE-REF"`
+}
+
+type reqifStringValue struct {
+	Value      string `xml:"THE-VALUE,attr"`
+	Definition string `xml:"DEFINITION>ATTRIBUTE-DEFINITION-STRING-REF"`
+}
+
+type reqifXHTMLValue struct {
+	Value      reqifXHTML `xml:"THE-VALUE"`
+	Definition string     `xml:"DEFINITION>ATTRIBUTE-DEFINITION-XHTML-REF"`
+}
+
+type reqifXHTML struct {
+	Content []byte `xml:",innerxml"`
+}
+
+type reqifSpecRelation struct {
+	Identifier string `xml:"IDENTIFIER,attr"`
+	LastChange string `xml:"LAST-CHANGE,attr"`
+	Type       string `xml:"TYPE>SPEC-RELATION-TYPE-REF"`
+	Source     string `xml:"SOURCE>SPEC-OBJECT-REF"`
+	Target     string `xml:"TARGET>SPEC-OBJECT-REF"`
+}
+
+type reqifSpecificationEntry struct {
+	reqifIdentifiable
+	Type     string           `xml:"TYPE>SPECIFICATION-TYPE-REF"`
+	Children []reqifHierarchy `xml:"CHILDREN>SPEC-HIERARCHY"`
+}
+
+type reqifHierarchy struct {
+	Identifier string           `xml:"IDENTIFIER,attr"`
+	LastChange string           `xml:"LAST-CHANGE,attr"`
+	Object     string           `xml:"OBJECT>SPEC-OBJECT-REF"`
+	Children   []reqifHierarchy `xml:"CHILDREN>SPEC-HIERARCHY"`
+}
+
+// WriteReqIF renders the project as a ReqIF document with one SPECIFICATION whose SPEC-HIERARCHY
+// mirrors the requirement tree. Links become SPEC-RELATIONs typed by link type.
+func WriteReqIF(w io.Writer, project *types.Project) error {
+	now := reqifTime(time.Now())
+
+	content := reqifContent{
+		Datatypes: []reqifIdentifiable{
+			{Identifier: reqifStringType, LongName: "String", LastChange: now, MaxLength: "65535"},
+		},
+		SpecificationTypes: []reqifIdentifiable{
+			{Identifier: reqifSpecificationType, LongName: "Requirements Document", LastChange: now},
+		},
+	}
+
+	objectType := reqifSpecObjectType{
+		reqifIdentifiable: reqifIdentifiable{Identifier: reqifRequirementType, LongName: "Requirement", LastChange: now},
+	}
+	for _, name := range reqifAttributes {
+		objectType.StringAttributes = append(objectType.StringAttributes, reqifAttributeDefinition{
+			reqifIdentifiable: reqifIdentifiable{Identifier: reqifAttributeID(name), LongName: name, LastChange: now},
+			Type:              reqifStringType,
+		})
+	}
+	content.SpecObjectTypes = []reqifSpecObjectType{objectType}
+
+	for _, linkType := range types.LinkTypes {
+		content.SpecRelationTypes = append(content.SpecRelationTypes, reqifIdentifiable{
+			Identifier: reqifRelationTypeIDs + linkType, LongName: linkType, LastChange: now,
+		})
+	}
+
+	project.Walk(func(req *types.Requirement) {
+		content.SpecObjects = append(content.SpecObjects, reqifObject(req))
+		for _, link := range req.Links {
+			target := project.FindRequirement(link.Target)
+			if target == nil {
+				continue
+			}
+			content.SpecRelations = append(content.SpecRelations, reqifSpecRelation{
+				Identifier: fmt.Sprintf("%s-%s-%s", reqifObjectID(req), link.Type, reqifObjectID(target)),
+				LastChange: reqifTime(req.Updated),
+				Type:       reqifRelationTypeIDs + link.Type,
+				Source:     reqifObjectID(req),
+				Target:     reqifObjectID(target),
+			})
+		}
+	})
+
+	content.Specifications = []reqifSpecificationEntry{{
+		reqifIdentifiable: reqifIdentifiable{Identifier: "reqd-specification-1", LongName: project.Name, LastChange: now},
+		Type:              reqifSpecificationType,
+		Children:          reqifHierarchies(project.Requirements),
+	}}
+
+	document := reqifDocument{
+		Header: reqifHeader{
+			Identifier:   "reqd-header",
+			CreationTime: now,
+			ReqIFToolID:  "reqd",
+			ReqIFVersion: "1.0",
+			SourceToolID: "reqd",
+			Title:        project.Name,
+		},
+		Content: content,
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to write ReqIF: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// reqifObject converts a requirement to a SPEC-OBJECT with one string value per non-empty field
+func reqifObject(req *types.Requirement) reqifSpecObject {
+	object := reqifSpecObject{
+		Identifier: reqifObjectID(req),
+		LastChange: reqifTime(req.Updated),
+		Type:       reqifRequirementType,
+	}
+
+	values := map[string]string{
+		reqifForeignID:  req.UID,
+		reqifID:         req.ID,
+		reqifText:       req.Text,
+		reqifStatus:     req.Status,
+		reqifPriority:   req.Priority,
+		reqifTags:       strings.Join(req.Tags, ", "),
+		reqifRationale:  req.Rationale,
+		reqifSource:     req.Source,
+		reqifOwner:      req.Owner,
+		reqifAcceptance: strings.Join(req.Acceptance, "\n"),
+	}
+	if !req.Created.IsZero() {
+		values[reqifCreated] = reqifTime(req.Created)
+	}
+
+	for _, name := range reqifAttributes {
+		if values[name] != "" {
+			object.StringValues = append(object.StringValues, reqifStringValue{Value: values[name], Definition: reqifAttributeID(name)})
+		}
+	}
+	return object
+}
+
+// reqifHierarchies converts a list of requirements and their descendants to SPEC-HIERARCHY elements
+func reqifHierarchies(requirements []types.Requirement) []reqifHierarchy {
+	var hierarchies []reqifHierarchy
+	for i := range requirements {
+		req := &requirements[i]
+		hierarchies = append(hierarchies, reqifHierarchy{
+			Identifier: "reqd-hierarchy-" + reqifObjectID(req),
+			LastChange: reqifTime(req.Updated),
+			Object:     reqifObjectID(req),
+			Children:   reqifHierarchies(req.Children),
+		})
+	}
+	return hierarchies
+}
+
+// reqifObjectID returns the SPEC-OBJECT identifier of a requirement: its UID, or its positional
+// ID for files that have not been migrated yet
+func reqifObjectID(req *types.Requirement) string {
+	if req.UID != "" {
+		return req.UID
+	}
+	return "reqd-" + strings.ReplaceAll(req.ID, ".", "-")
+}
+
+// reqifAttributeID returns the identifier of an attribute definition, e.g. "reqd-attribute-ReqIF-Text"
+func reqifAttributeID(name string) string {
+	return "reqd-attribute-" + strings.ReplaceAll(name, ".", "-")
+}
+
+// reqifTime formats a timestamp as an xsd:dateTime
+func reqifTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ReadReqIF reads a ReqIF document into a project. The SPEC-HIERARCHY of every SPECIFICATION
+// becomes the requirement tree, with positional IDs assigned in document order; SPEC-OBJECTs
+// that are not part of any hierarchy are added at the top level. Requirement text is taken from
+// the ReqIF.Text attribute or a common alternative such as "Object Text". UIDs are kept when
+// ReqIF.ForeignID holds a reqd UID; other objects, e.g. from DOORS or Polarion, take their
+// SPEC-OBJECT identifier as UID so that relations to them are kept until an import assigns
+// real UIDs. SPEC-RELATIONs become links when their type is a known link type.
+func ReadReqIF(r io.Reader) (*types.Project, error) {
+	var document reqifDocument
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("failed to parse ReqIF: %w", err)
+	}
+	content := &document.Content
+
+	// Map attribute definitions and relation types to their names
+	attributeNames := make(map[string]string)
+	for _, objectType := range content.SpecObjectTypes {
+		for _, def := range slices.Concat(objectType.StringAttributes, objectType.XHTMLAttributes) {
+			attributeNames[def.Identifier] = def.LongName
+		}
+	}
+	relationTypes := make(map[string]string)
+	for _, relationType := range content.SpecRelationTypes {
+		relationTypes[relationType.Identifier] = relationType.LongName
+	}
+
+	objects := make(map[string]*types.Requirement)
+	for _, object := range content.SpecObjects {
+		req, err := reqifRequirement(object, attributeNames)
+		if err != nil {
+			return nil, err
+		}
+		if req.UID == "" {
+			req.UID = object.Identifier
+		}
+		objects[object.Identifier] = req
+	}
+
+	for _, relation := range content.SpecRelations {
+		source, target := objects[relation.Source], objects[relation.Target]
+		if source == nil || target == nil {
+			continue
+		}
+		if linkType, err := types.ParseLinkType(relationTypes[relation.Type]); err == nil {
+			source.AddLink(linkType, target.UID)
+		}
+	}
+
+	project := &types.Project{Name: document.Header.Title}
+	placed := make(map[string]bool)
+	for _, specification := range content.Specifications {
+		if project.Name == "" {
+			project.Name = specification.LongName
+		}
+		project.Requirements = append(project.Requirements, reqifTree(specification.Children, objects, placed)...)
+	}
+	for _, object := range content.SpecObjects {
+		if !placed[object.Identifier] {
+			placed[object.Identifier] = true
+			project.Requirements = append(project.Requirements, *objects[object.Identifier])
+		}
+	}
+
+	numberRequirements(project.Requirements, "")
+	return project, nil
+}
+
+// reqifRequirement converts a SPEC-OBJECT to a requirement without children
+func reqifRequirement(object reqifSpecObject, attributeNames map[string]string) (*types.Requirement, error) {
+	values := make(map[string]string)
+	for _, value := range object.StringValues {
+		values[attributeNames[value.Definition]] = value.Value
+	}
+	for _, value := range object.XHTMLValues {
+		text, err := xhtmlText(value.Value.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read text of '%s': %w", object.Identifier, err)
+		}
+		values[attributeNames[value.Definition]] = text
+	}
+
+	req := &types.Requirement{
+		Status:    values[reqifStatus],
+		Priority:  values[reqifPriority],
+		Rationale: values[reqifRationale],
+		Source:    values[reqifSource],
+		Owner:     values[reqifOwner],
+	}
+	for _, name := range reqifTextNames {
+		if values[name] != "" {
+			req.Text = values[name]
+			break
+		}
+	}
+	if types.IsUID(values[reqifForeignID]) {
+		req.UID = strings.ToUpper(values[reqifForeignID])
+	}
+	for _, tag := range strings.Split(values[reqifTags], ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			req.AddTag(tag)
+		}
+	}
+	for _, criterion := range strings.Split(values[reqifAcceptance], "\n") {
+		if criterion = strings.TrimSpace(criterion); criterion != "" {
+			req.Acceptance = append(req.Acceptance, criterion)
+		}
+	}
+	if created, err := time.Parse(time.RFC3339, values[reqifCreated]); err == nil {
+		req.Created = created
+	}
+	if updated, err := time.Parse(time.RFC3339, object.LastChange); err == nil && updated.After(time.Time{}) {
+		req.Updated = updated
+	}
+
+	return req, nil
+}
+
+// reqifTree builds the requirement tree of a SPEC-HIERARCHY, skipping references to unknown
+// objects and objects that were already placed elsewhere in the tree
+func reqifTree(hierarchies []reqifHierarchy, objects map[string]*types.Requirement, placed map[string]bool) []types.Requirement {
+	var requirements []types.Requirement
+	for _, hierarchy := range hierarchies {
+		object := objects[hierarchy.Object]
+		if object == nil || placed[hierarchy.Object] {
+			continue
+		}
+		placed[hierarchy.Object] = true
+
+		req := *object
+		req.Children = reqifTree(hierarchy.Children, objects, placed)
+		requirements = append(requirements, req)
+	}
+	return requirements
+}
+
+// numberRequirements assigns positional IDs to requirements and their descendants in tree order
+func numberRequirements(requirements []types.Requirement, parentID string) {
+	for i := range requirements {
+		requirements[i].ID = types.ChildID(parentID, i+1)
+		numberRequirements(requirements[i].Children, requirements[i].ID)
+	}
+}
+
+// xhtmlText returns the text content of an XHTML fragment with whitespace collapsed
+func xhtmlText(fragment []byte) (string, error) {
+	var text strings.Builder
+	decoder := xml.NewDecoder(bytes.NewReader(fragment))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if data, ok := token.(xml.CharData); ok {
+			text.Write(data)
+			text.WriteByte(' ')
+		}
+	}
+	return strings.Join(strings.Fields(text.String()), " "), nil
+}
@@ -0,0 +1,225 @@
+package formats
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+func TestReqIF_RoundTrip(t *testing.T) {
+	original := testProject()
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	updated := time.Date(2025, 1, 2, 14, 30, 0, 0, time.UTC)
+	original.Requirements[0].Source = "Kickoff workshop"
+	original.Requirements[0].Owner = "Product team"
+	original.Requirements[0].Created = created
+	original.Requirements[0].Updated = updated
+	original.Requirements[0].Children[0].Acceptance = append(original.Requirements[0].Children[0].Acceptance,
+		"Given a valid address, when submitted, then the form is accepted")
+	original.Requirements[0].Children[0].Links = []types.Link{{Type: types.LinkRefines, Target: "REQ-0001"}}
+
+	var out bytes.Buffer
+	if err := WriteReqIF(&out, original); err != nil {
+		t.Fatalf("WriteReqIF() error = %v", err)
+	}
+
+	imported, err := ReadReqIF(&out)
+	if err != nil {
+		t.Fatalf("ReadReqIF() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(imported, original) {
+		t.Errorf("ReadReqIF(WriteReqIF()) = %+v, want %+v", imported, original)
+	}
+}
+
+func TestWriteReqIF_Structure(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteReqIF(&out, testProject()); err != nil {
+		t.Fatalf("WriteReqIF() error = %v", err)
+	}
+	doc := out.String()
+
+	for _, expected := range []string{
+		`<REQ-IF xmlns="http://www.omg.org/spec/ReqIF/20110401/reqif.xsd">`,
+		"<TITLE>Portal</TITLE>",
+		`<SPEC-OBJECT IDENTIFIER="REQ-0002"`,
+		`<ATTRIBUTE-VALUE-STRING THE-VALUE="The login form MUST validate [email] addresses">`,
+		"<SPEC-RELATION-TYPE-REF>reqd-relation-depends-on</SPEC-RELATION-TYPE-REF>",
+		"<SPEC-OBJECT-REF>REQ-0001</SPEC-OBJECT-REF>",
+	} {
+		if !strings.Contains(doc, expected) {
+			t.Errorf("WriteReqIF() output is missing %q", expected)
+		}
+	}
+}
+
+func TestReadReqIF_ForeignDocument(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<REQ-IF xmlns="http://www.omg.org/spec/ReqIF/20110401/reqif.xsd" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <THE-HEADER><REQ-IF-HEADER IDENTIFIER="h"><TITLE>Vehicle</TITLE></REQ-IF-HEADER></THE-HEADER>
+  <CORE-CONTENT>
+    <REQ-IF-CONTENT>
+      <SPEC-TYPES>
+        <SPEC-OBJECT-TYPE IDENTIFIER="t" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <SPEC-ATTRIBUTES>
+            <ATTRIBUTE-DEFINITION-XHTML IDENTIFIER="text" LONG-NAME="Object Text" LAST-CHANGE="2024-05-01T00:00:00Z"/>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="fid" LONG-NAME="ReqIF.ForeignID" LAST-CHANGE="2024-05-01T00:00:00Z"/>
+          </SPEC-ATTRIBUTES>
+        </SPEC-OBJECT-TYPE>
+      </SPEC-TYPES>
+      <SPEC-OBJECTS>
+        <SPEC-OBJECT IDENTIFIER="o1" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <VALUES>
+            <ATTRIBUTE-VALUE-XHTML>
+              <DEFINITION><ATTRIBUTE-DEFINITION-XHTML-REF>text</ATTRIBUTE-DEFINITION-XHTML-REF></DEFINITION>
+              <THE-VALUE><xhtml:div>The vehicle <xhtml:b>SHALL</xhtml:b> brake.</xhtml:div></THE-VALUE>
+            </ATTRIBUTE-VALUE-XHTML>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="1042">
+              <DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>fid</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION>
+            </ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="o2" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <TYPE><SPEC-OBJECT-TYPE-REF>t</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="o3" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <TYPE><SPEC-OBJECT-TYPE-REF>t</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+      </SPEC-OBJECTS>
+      <SPECIFICATIONS>
+        <SPECIFICATION IDENTIFIER="s" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <CHILDREN>
+            <SPEC-HIERARCHY IDENTIFIER="h1" LAST-CHANGE="2024-05-01T00:00:00Z">
+              <OBJECT><SPEC-OBJECT-REF>o1</SPEC-OBJECT-REF></OBJECT>
+              <CHILDREN>
+                <SPEC-HIERARCHY IDENTIFIER="h2" LAST-CHANGE="2024-05-01T00:00:00Z">
+                  <OBJECT><SPEC-OBJECT-REF>o2</SPEC-OBJECT-REF></OBJECT>
+                </SPEC-HIERARCHY>
+              </CHILDREN>
+            </SPEC-HIERARCHY>
+          </CHILDREN>
+        </SPECIFICATION>
+      </SPECIFICATIONS>
+    </REQ-IF-CONTENT>
+  </CORE-CONTENT>
+</REQ-IF>`
+
+	project, err := ReadReqIF(strings.NewReader(document))
+	if err != nil {
+		t.Fatalf("ReadReqIF() error = %v", err)
+	}
+
+	if project.Name != "Vehicle" {
+		t.Errorf("Name = %q, want Vehicle", project.Name)
+	}
+	if len(project.Requirements) != 2 {
+		t.Fatalf("len(Requirements) = %d, want 2 (o1 and the unplaced o3)", len(project.Requirements))
+	}
+	first := project.Requirements[0]
+	if first.Text != "The vehicle SHALL brake." {
+		t.Errorf("Text = %q, want text of the XHTML value", first.Text)
+	}
+	if first.UID != "o1" {
+		t.Errorf("UID = %q, want the SPEC-OBJECT identifier for a foreign ID that is not a reqd UID", first.UID)
+	}
+	if len(first.Children) != 1 || first.Children[0].ID != "1.1" {
+		t.Errorf("Children = %+v, want o2 as 1.1", first.Children)
+	}
+	if project.Requirements[1].ID != "2" {
+		t.Errorf("unplaced object ID = %q, want 2", project.Requirements[1].ID)
+	}
+}
+
+func TestReadReqIF_ForeignRelations(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<REQ-IF xmlns="http://www.omg.org/spec/ReqIF/20110401/reqif.xsd">
+  <THE-HEADER><REQ-IF-HEADER IDENTIFIER="h"><TITLE>Vehicle</TITLE></REQ-IF-HEADER></THE-HEADER>
+  <CORE-CONTENT>
+    <REQ-IF-CONTENT>
+      <SPEC-TYPES>
+        <SPEC-OBJECT-TYPE IDENTIFIER="t" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <SPEC-ATTRIBUTES>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="text" LONG-NAME="ReqIF.Text" LAST-CHANGE="2024-05-01T00:00:00Z"/>
+          </SPEC-ATTRIBUTES>
+        </SPEC-OBJECT-TYPE>
+        <SPEC-RELATION-TYPE IDENTIFIER="rt" LONG-NAME="Refines" LAST-CHANGE="2024-05-01T00:00:00Z"/>
+      </SPEC-TYPES>
+      <SPEC-OBJECTS>
+        <SPEC-OBJECT IDENTIFIER="_brake" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="The vehicle SHALL brake.">
+              <DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>text</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION>
+            </ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="_abs" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="The vehicle SHALL prevent wheel lock while braking.">
+              <DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>text</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION>
+            </ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+      </SPEC-OBJECTS>
+      <SPEC-RELATIONS>
+        <SPEC-RELATION IDENTIFIER="r1" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <TYPE><SPEC-RELATION-TYPE-REF>rt</SPEC-RELATION-TYPE-REF></TYPE>
+          <SOURCE><SPEC-OBJECT-REF>_abs</SPEC-OBJECT-REF></SOURCE>
+          <TARGET><SPEC-OBJECT-REF>_brake</SPEC-OBJECT-REF></TARGET>
+        </SPEC-RELATION>
+      </SPEC-RELATIONS>
+      <SPECIFICATIONS>
+        <SPECIFICATION IDENTIFIER="s" LAST-CHANGE="2024-05-01T00:00:00Z">
+          <CHILDREN>
+            <SPEC-HIERARCHY IDENTIFIER="h1" LAST-CHANGE="2024-05-01T00:00:00Z">
+              <OBJECT><SPEC-OBJECT-REF>_brake</SPEC-OBJECT-REF></OBJECT>
+              <CHILDREN>
+                <SPEC-HIERARCHY IDENTIFIER="h2" LAST-CHANGE="2024-05-01T00:00:00Z">
+                  <OBJECT><SPEC-OBJECT-REF>_abs</SPEC-OBJECT-REF></OBJECT>
+                </SPEC-HIERARCHY>
+              </CHILDREN>
+            </SPEC-HIERARCHY>
+          </CHILDREN>
+        </SPECIFICATION>
+      </SPECIFICATIONS>
+    </REQ-IF-CONTENT>
+  </CORE-CONTENT>
+</REQ-IF>`
+
+	project, err := ReadReqIF(strings.NewReader(document))
+	if err != nil {
+		t.Fatalf("ReadReqIF() error = %v", err)
+	}
+
+	want := []types.Link{{Type: types.LinkRefines, Target: "_brake"}}
+	abs := project.FindRequirement("1.1")
+	if abs == nil || !reflect.DeepEqual(abs.Links, want) {
+		t.Fatalf("links of 1.1 = %+v, want %+v", abs, want)
+	}
+
+	// The relation survives writing and reading the document again
+	var out bytes.Buffer
+	if err := WriteReqIF(&out, project); err != nil {
+		t.Fatalf("WriteReqIF() error = %v", err)
+	}
+	reread, err := ReadReqIF(&out)
+	if err != nil {
+		t.Fatalf("ReadReqIF() error = %v", err)
+	}
+	if !reflect.DeepEqual(reread, project) {
+		t.Errorf("ReadReqIF(WriteReqIF()) = %+v, want %+v", reread, project)
+	}
+}
+
+func TestReadReqIF_Invalid(t *testing.T) {
+	if _, err := ReadReqIF(strings.NewReader("<not-reqif/>")); err == nil {
+		t.Error("ReadReqIF() of a non-ReqIF document should fail")
+	}
+}
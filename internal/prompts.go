@@ -72,5 +72,69 @@ Here is the list of possible parents:
 
 Here is the requirement statement to analyze:
 "{{.Requirement}}"
+`
+
+	DraftAcceptanceCriteriaPrompt = `
+You are a Software Quality Assurance Analyst.
+
+Your task is to draft acceptance criteria for the provided software requirement statement.
+
+Follow these rules:
+
+1. Write each criterion in **Given/When/Then** form (e.g., "Given a registered user, when they submit valid credentials, then the system displays the dashboard").
+2. Make each criterion **testable**: it states one observable outcome that a tester can verify.
+3. Cover the main success case first, then relevant error and boundary cases.
+4. Do not add behavior that the requirement does not state or clearly imply.
+5. Return between 1 and 5 criteria.
+
+---
+
+Return the criteria strictly as a structured JSON object matching this schema:
+{
+  "criteria": ["<Given/When/Then acceptance criterion>"]
+}
+
+Here is the requirement statement to analyze:
+"{{.Requirement}}"
+`
+
+	FindConflictsPrompt = `
+You are a Requirements Consistency Reviewer.
+
+Your task is to review a group of related software requirements together and find problems that only show when the requirements are read side by side.
+
+Each requirement is represented in the format:
+<id>: <requirement text>
+Acceptance criteria, if any, follow as indented "- " lines.
+
+Report the following kinds of findings:
+
+1. **contradiction**: two or more requirements cannot all be satisfied, e.g. different limits for the same value or opposite behavior in the same situation.
+2. **overlap**: two or more requirements state the same thing, fully or in part, so that they may drift apart when one is changed.
+3. **missing_precondition**: a requirement depends on a capability, state or actor that no requirement in the group establishes.
+
+Follow these rules:
+
+1. Only report findings that are supported by the text of the requirements. Do not report style issues.
+2. Reference requirements only by the IDs given in the list.
+3. A contradiction or overlap references at least two requirements; a missing precondition references the requirement that lacks it.
+4. Describe each finding in one or two short sentences.
+5. If there are no findings, return an empty list.
+
+---
+
+Return the findings strictly as a structured JSON object matching this schema:
+{
+  "findings": [
+    {
+      "type": "<contradiction, overlap or missing_precondition>",
+      "requirements": ["<ID of a requirement involved>"],
+      "description": "<What the problem is>"
+    }
+  ]
+}
+
+Here is the list of requirements to review:
+"{{.Requirements}}"
 `
 )
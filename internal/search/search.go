@@ -0,0 +1,165 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+// Fields a query term can be scoped to with a "<field>:" prefix
+const (
+	FieldText   = "text"
+	FieldTag    = "tag"
+	FieldStatus = "status"
+)
+
+// Fields lists every field a term can be scoped to
+var Fields = []string{FieldText, FieldTag, FieldStatus}
+
+// Query is a parsed search query. A requirement matches when it matches every term.
+type Query struct {
+	terms []term
+}
+
+// term is one query term; an empty field searches the text, acceptance criteria and rationale
+type term struct {
+	field   string
+	pattern *regexp.Regexp
+}
+
+// Parse parses a query of whitespace-separated terms. Double quotes group words into one term,
+// e.g. `text:"log in"`. Terms are case-insensitive substrings, or regular expressions when regex
+// is set. Tag and status terms match whole values unless they are regular expressions.
+func Parse(query string, regex bool) (*Query, error) {
+	words, err := splitTerms(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("empty search query")
+	}
+
+	q := &Query{}
+	for _, word := range words {
+		field, value := "", word
+		if prefix, rest, ok := strings.Cut(word, ":"); ok && slices.Contains(Fields, strings.ToLower(prefix)) {
+			field, value = strings.ToLower(prefix), rest
+		}
+		if value == "" {
+			return nil, fmt.Errorf("empty search term '%s'", word)
+		}
+
+		var expr string
+		switch {
+		case regex:
+			expr = value
+		case field == FieldTag || field == FieldStatus:
+			expr = "^" + regexp.QuoteMeta(value) + "$"
+		default:
+			expr = regexp.QuoteMeta(value)
+		}
+
+		pattern, err := regexp.Compile("(?i)" + expr)
+		if err != nil {
+			// Report the problem without the flags added above
+			var syntaxErr *syntax.Error
+			if errors.As(err, &syntaxErr) {
+				return nil, fmt.Errorf("invalid regular expression '%s': %s", value, syntaxErr.Code)
+			}
+			return nil, fmt.Errorf("invalid regular expression '%s': %w", value, err)
+		}
+		q.terms = append(q.terms, term{field: field, pattern: pattern})
+	}
+
+	return q, nil
+}
+
+// splitTerms splits a query at whitespace outside double quotes
+func splitTerms(query string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord, quoted := false, false
+
+	for _, c := range query {
+		switch {
+		case c == '"':
+			quoted = !quoted
+			inWord = true
+		case !quoted && (c == ' ' || c == '\t' || c == '\n'):
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(c)
+			inWord = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in search query")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// Matches reports whether the requirement matches every term of the query
+func (q *Query) Matches(req *types.Requirement) bool {
+	for _, t := range q.terms {
+		if !t.matches(req) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether the requirement matches the term
+func (t term) matches(req *types.Requirement) bool {
+	switch t.field {
+	case FieldText:
+		return t.pattern.MatchString(req.Text)
+	case FieldTag:
+		return slices.ContainsFunc(req.Tags, t.pattern.MatchString)
+	case FieldStatus:
+		return t.pattern.MatchString(req.CurrentStatus())
+	}
+	return t.pattern.MatchString(req.Text) ||
+		slices.ContainsFunc(req.Acceptance, t.pattern.MatchString) ||
+		t.pattern.MatchString(req.Rationale)
+}
+
+// Highlights returns the sorted, non-overlapping byte ranges of text matched by the query. field
+// is FieldText for a requirement's text, which text and unscoped terms match, and empty for its
+// acceptance criteria and rationale, which only unscoped terms match.
+func (q *Query) Highlights(field, text string) [][2]int {
+	var ranges [][2]int
+	for _, t := range q.terms {
+		if t.field == "" || t.field == field {
+			for _, match := range t.pattern.FindAllStringIndex(text, -1) {
+				if match[0] < match[1] {
+					ranges = append(ranges, [2]int{match[0], match[1]})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(ranges, func(a, b [2]int) int { return a[0] - b[0] })
+
+	// Merge overlapping ranges
+	var merged [][2]int
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+		} else {
+			merged = append(merged, r)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/techcorrectco/reqd/internal/types"
+)
+
+func testRequirement() *types.Requirement {
+	return &types.Requirement{
+		ID:         "1.2",
+		UID:        "REQ-0002",
+		Text:       "Users MUST be able to log in with a password",
+		Status:     "approved",
+		Tags:       []string{"security", "auth"},
+		Acceptance: []string{"Given a locked account When the user logs in Then access is denied"},
+		Rationale:  "Accounts hold personal data",
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		regex   bool
+		wantErr bool
+	}{
+		{"substring", "log in", false, false},
+		{"field scoped", "tag:security status:approved", false, false},
+		{"quoted phrase", `text:"log in"`, false, false},
+		{"regex", "pass(word|phrase)", true, false},
+		{"empty query", "  ", false, true},
+		{"empty term", "tag:", false, true},
+		{"unterminated quote", `"log in`, false, true},
+		{"invalid regex", "pass(", true, true},
+		{"regex characters are literal without --regex", "pass(", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query, tt.regex)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse(%q) error = %v, wantErr %v", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuery_Matches(t *testing.T) {
+	req := testRequirement()
+
+	tests := []struct {
+		name  string
+		query string
+		regex bool
+		want  bool
+	}{
+		{"substring is case-insensitive", "LOG IN", false, true},
+		{"all terms must match", "password biometric", false, false},
+		{"unscoped term searches acceptance criteria", "locked", false, true},
+		{"unscoped term searches rationale", "personal", false, true},
+		{"text scope ignores acceptance criteria", "text:locked", false, false},
+		{"quoted phrase", `text:"log in with"`, false, true},
+		{"tag matches whole tags", "tag:sec", false, false},
+		{"tag", "tag:Security", false, true},
+		{"status", "status:approved", false, true},
+		{"other status", "status:draft", false, false},
+		{"unknown prefix is part of the term", "https://example.com", false, false},
+		{"regex", `pass\w+`, true, true},
+		{"regex on tags", "tag:^sec", true, true},
+		{"regex without match", `^password`, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.query, tt.regex)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.query, err)
+			}
+			if got := q.Matches(req); got != tt.want {
+				t.Errorf("Matches(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_MatchesDraftStatus(t *testing.T) {
+	q, err := Parse("status:draft", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Matches(&types.Requirement{Text: "Unreviewed"}) {
+		t.Error("requirements without a status should match status:draft")
+	}
+}
+
+func TestQuery_Highlights(t *testing.T) {
+	q, err := Parse(`log "log in" text:password tag:auth`, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	text := testRequirement().Text
+	got := q.Highlights(FieldText, text)
+	want := [][2]int{{22, 28}, {36, 44}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Highlights(text) = %v, want %v", got, want)
+	}
+
+	// Text-scoped terms do not highlight other fields
+	if got := q.Highlights("", "password log"); !reflect.DeepEqual(got, [][2]int{{9, 12}}) {
+		t.Errorf("Highlights(criterion) = %v, want [[9 12]]", got)
+	}
+}
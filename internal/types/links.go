@@ -0,0 +1,89 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Link types between requirements
+const (
+	LinkDependsOn     = "depends-on"
+	LinkConflictsWith = "conflicts-with"
+	LinkRefines       = "refines"
+	LinkDuplicates    = "duplicates"
+)
+
+// LinkTypes lists every supported link type
+var LinkTypes = []string{LinkDependsOn, LinkConflictsWith, LinkRefines, LinkDuplicates}
+
+// Link is a typed relationship from one requirement to another, referring to the target by UID
+// so that it survives renumbering
+type Link struct {
+	Type   string `json:"type" yaml:"type"`
+	Target string `json:"target" yaml:"target"`
+}
+
+// InboundLink is a link pointing at a requirement, together with the requirement it comes from
+type InboundLink struct {
+	Type   string
+	Source *Requirement
+}
+
+// ParseLinkType validates a link type name
+func ParseLinkType(value string) (string, error) {
+	linkType := strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains(LinkTypes, linkType) {
+		return "", fmt.Errorf("unknown link type '%s' (known link types: %s)", value, strings.Join(LinkTypes, ", "))
+	}
+	return linkType, nil
+}
+
+// AddLink links the requirement to the target and reports whether the link is new
+func (r *Requirement) AddLink(linkType, targetUID string) bool {
+	link := Link{Type: linkType, Target: targetUID}
+	if slices.Contains(r.Links, link) {
+		return false
+	}
+	r.Links = append(r.Links, link)
+	return true
+}
+
+// RemoveLink removes a link from the requirement and reports whether it was present
+func (r *Requirement) RemoveLink(linkType, targetUID string) bool {
+	i := slices.Index(r.Links, Link{Type: linkType, Target: targetUID})
+	if i < 0 {
+		return false
+	}
+	r.Links = slices.Delete(r.Links, i, i+1)
+	return true
+}
+
+// InboundLinks returns the links from other requirements that point at the given UID
+func (p *Project) InboundLinks(uid string) []InboundLink {
+	var inbound []InboundLink
+	p.Walk(func(req *Requirement) {
+		for _, link := range req.Links {
+			if link.Target == uid {
+				inbound = append(inbound, InboundLink{Type: link.Type, Source: req})
+			}
+		}
+	})
+	return inbound
+}
+
+// RemoveLinksTo deletes every link whose target is one of the given UIDs and returns how many were removed
+func (p *Project) RemoveLinksTo(uids []string) int {
+	removed := 0
+	p.Walk(func(req *Requirement) {
+		before := len(req.Links)
+		req.Links = slices.DeleteFunc(req.Links, func(link Link) bool {
+			return slices.Contains(uids, link.Target)
+		})
+		if len(req.Links) == 0 {
+			req.Links = nil
+		}
+		removed += before - len(req.Links)
+	})
+	return removed
+}
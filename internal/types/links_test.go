@@ -0,0 +1,50 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProject_RemoveLinksTo(t *testing.T) {
+	project := &Project{
+		Name: "test",
+		Requirements: []Requirement{
+			{
+				ID:   "1",
+				UID:  "REQ-0001",
+				Text: "Root 1",
+				Links: []Link{
+					{Type: LinkDependsOn, Target: "REQ-0002"},
+					{Type: LinkRefines, Target: "REQ-0003"},
+				},
+				Children: []Requirement{
+					{
+						ID:    "1.1",
+						UID:   "REQ-0004",
+						Text:  "Child 1.1",
+						Links: []Link{{Type: LinkDuplicates, Target: "REQ-0002"}},
+					},
+				},
+			},
+			{ID: "2", UID: "REQ-0002", Text: "Root 2"},
+			{ID: "3", UID: "REQ-0003", Text: "Root 3"},
+		},
+	}
+
+	if inbound := project.InboundLinks("REQ-0002"); len(inbound) != 2 {
+		t.Errorf("InboundLinks() = %v, want 2 links", inbound)
+	}
+
+	if removed := project.RemoveLinksTo([]string{"REQ-0002"}); removed != 2 {
+		t.Errorf("RemoveLinksTo() = %d, want 2", removed)
+	}
+
+	expected := []Link{{Type: LinkRefines, Target: "REQ-0003"}}
+	if !reflect.DeepEqual(project.Requirements[0].Links, expected) {
+		t.Errorf("RemoveLinksTo() links = %v, want %v", project.Requirements[0].Links, expected)
+	}
+
+	if links := project.Requirements[0].Children[0].Links; links != nil {
+		t.Errorf("RemoveLinksTo() child links = %v, want nil", links)
+	}
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// MoSCoW priorities of a requirement
+const (
+	PriorityMust   = "must"
+	PriorityShould = "should"
+	PriorityCould  = "could"
+	PriorityWont   = "wont"
+)
+
+// Priorities lists the MoSCoW priorities from highest to lowest
+var Priorities = []string{PriorityMust, PriorityShould, PriorityCould, PriorityWont}
+
+// rfc2119Keyword matches the RFC 2119 keywords that imply a priority
+var rfc2119Keyword = regexp.MustCompile(`\b(MUST|SHALL|REQUIRED|SHOULD|RECOMMENDED|MAY|OPTIONAL)\b`)
+
+// keywordPriorities maps each RFC 2119 keyword to the priority it implies
+var keywordPriorities = map[string]string{
+	"MUST":        PriorityMust,
+	"SHALL":       PriorityMust,
+	"REQUIRED":    PriorityMust,
+	"SHOULD":      PriorityShould,
+	"RECOMMENDED": PriorityShould,
+	"MAY":         PriorityCould,
+	"OPTIONAL":    PriorityCould,
+}
+
+// ParsePriority normalizes a priority name, accepting any case and "won't"
+func ParsePriority(value string) (string, error) {
+	priority := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), "'", "")
+	if !slices.Contains(Priorities, priority) {
+		return "", fmt.Errorf("unknown priority '%s' (known priorities: %s)", value, strings.Join(Priorities, ", "))
+	}
+	return priority, nil
+}
+
+// PriorityRank orders priorities from highest (0) to lowest, placing unset priorities last
+func PriorityRank(priority string) int {
+	if i := slices.Index(Priorities, priority); i >= 0 {
+		return i
+	}
+	return len(Priorities)
+}
+
+// PriorityConflict describes a mismatch between a priority and the RFC 2119 keyword in the text,
+// or returns an empty string when they agree
+func PriorityConflict(priority, text string) string {
+	if priority == "" || priority == PriorityWont {
+		return ""
+	}
+
+	keyword := rfc2119Keyword.FindString(text)
+	if keyword == "" {
+		return ""
+	}
+
+	if implied := keywordPriorities[keyword]; implied != priority {
+		return fmt.Sprintf("priority '%s' conflicts with RFC 2119 keyword %s, which implies '%s'", priority, keyword, implied)
+	}
+	return ""
+}
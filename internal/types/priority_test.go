@@ -0,0 +1,30 @@
+package types
+
+import "testing"
+
+func TestPriorityConflict(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority string
+		text     string
+		conflict bool
+	}{
+		{name: "no priority", priority: "", text: "The system MUST log errors.", conflict: false},
+		{name: "matching must", priority: PriorityMust, text: "The system MUST log errors.", conflict: false},
+		{name: "matching shall", priority: PriorityMust, text: "The system SHALL log errors.", conflict: false},
+		{name: "could with must", priority: PriorityCould, text: "The system MUST log errors.", conflict: true},
+		{name: "must with may", priority: PriorityMust, text: "The system MAY log errors.", conflict: true},
+		{name: "matching should not", priority: PriorityShould, text: "The system SHOULD NOT log secrets.", conflict: false},
+		{name: "lowercase keyword ignored", priority: PriorityCould, text: "The system must log errors.", conflict: false},
+		{name: "wont never conflicts", priority: PriorityWont, text: "The system MUST log errors.", conflict: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := PriorityConflict(tt.priority, tt.text)
+			if (result != "") != tt.conflict {
+				t.Errorf("PriorityConflict(%q, %q) = %q, want conflict %v", tt.priority, tt.text, result, tt.conflict)
+			}
+		})
+	}
+}
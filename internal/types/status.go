@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Lifecycle statuses of a requirement
+const (
+	StatusDraft       = "draft"
+	StatusProposed    = "proposed"
+	StatusApproved    = "approved"
+	StatusImplemented = "implemented"
+	StatusVerified    = "verified"
+	StatusDeprecated  = "deprecated"
+)
+
+// DefaultTransitions is the status transition graph used when the project does not define one
+var DefaultTransitions = map[string][]string{
+	StatusDraft:       {StatusProposed, StatusDeprecated},
+	StatusProposed:    {StatusApproved, StatusDraft, StatusDeprecated},
+	StatusApproved:    {StatusImplemented, StatusProposed, StatusDeprecated},
+	StatusImplemented: {StatusVerified, StatusApproved, StatusDeprecated},
+	StatusVerified:    {StatusImplemented, StatusDeprecated},
+	StatusDeprecated:  {StatusDraft},
+}
+
+// CurrentStatus returns the requirement's status, treating an unset status as draft
+func (r *Requirement) CurrentStatus() string {
+	if r.Status == "" {
+		return StatusDraft
+	}
+	return r.Status
+}
+
+// StatusTransitions returns the project's status transition graph, falling back to DefaultTransitions
+func (p *Project) StatusTransitions() map[string][]string {
+	if len(p.Transitions) > 0 {
+		return p.Transitions
+	}
+	return DefaultTransitions
+}
+
+// Statuses returns every status known to the project's transition graph in sorted order
+func (p *Project) Statuses() []string {
+	var statuses []string
+	for from, targets := range p.StatusTransitions() {
+		statuses = append(statuses, from)
+		statuses = append(statuses, targets...)
+	}
+	slices.Sort(statuses)
+	return slices.Compact(statuses)
+}
+
+// ValidateTransition returns an error when moving from one status to another is not allowed
+func (p *Project) ValidateTransition(from, to string) error {
+	if !slices.Contains(p.Statuses(), to) {
+		return fmt.Errorf("unknown status '%s' (known statuses: %s)", to, strings.Join(p.Statuses(), ", "))
+	}
+
+	allowed := p.StatusTransitions()[from]
+	if !slices.Contains(allowed, to) {
+		if len(allowed) == 0 {
+			return fmt.Errorf("no transitions are allowed from '%s'", from)
+		}
+		return fmt.Errorf("cannot change status from '%s' to '%s' (allowed: %s)", from, to, strings.Join(allowed, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package types
+
+import "testing"
+
+func TestProject_ValidateTransition(t *testing.T) {
+	custom := &Project{
+		Transitions: map[string][]string{
+			StatusDraft:    {StatusApproved},
+			StatusApproved: {"retired"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		project *Project
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "default allowed", project: &Project{}, from: StatusDraft, to: StatusProposed},
+		{name: "default skipping approval", project: &Project{}, from: StatusDraft, to: StatusImplemented, wantErr: true},
+		{name: "default unknown status", project: &Project{}, from: StatusDraft, to: "done", wantErr: true},
+		{name: "custom allowed", project: custom, from: StatusDraft, to: StatusApproved},
+		{name: "custom terminal status", project: custom, from: "retired", to: StatusDraft, wantErr: true},
+		{name: "custom status missing from graph", project: custom, from: StatusDraft, to: StatusProposed, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.project.ValidateTransition(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTransition(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
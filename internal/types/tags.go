@@ -0,0 +1,51 @@
+package types
+
+import (
+	"slices"
+	"strings"
+)
+
+// NormalizeTag trims and lowercases a tag so that tags compare case-insensitively
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// HasTag reports whether the requirement carries the tag
+func (r *Requirement) HasTag(tag string) bool {
+	return slices.Contains(r.Tags, NormalizeTag(tag))
+}
+
+// AddTag adds a tag to the requirement and reports whether it was not present yet
+func (r *Requirement) AddTag(tag string) bool {
+	tag = NormalizeTag(tag)
+	if tag == "" || r.HasTag(tag) {
+		return false
+	}
+	r.Tags = append(r.Tags, tag)
+	return true
+}
+
+// RemoveTag removes a tag from the requirement and reports whether it was present
+func (r *Requirement) RemoveTag(tag string) bool {
+	i := slices.Index(r.Tags, NormalizeTag(tag))
+	if i < 0 {
+		return false
+	}
+	r.Tags = slices.Delete(r.Tags, i, i+1)
+	return true
+}
+
+// MatchesTags reports whether the requirement satisfies every tag term.
+// A term of the form "!tag" requires the tag to be absent.
+func (r *Requirement) MatchesTags(terms []string) bool {
+	for _, term := range terms {
+		if tag, negated := strings.CutPrefix(term, "!"); negated {
+			if r.HasTag(tag) {
+				return false
+			}
+		} else if !r.HasTag(term) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package types
+
+import "testing"
+
+func TestRequirement_MatchesTags(t *testing.T) {
+	req := Requirement{ID: "1", Text: "Encrypt data at rest", Tags: []string{"security", "compliance"}}
+
+	tests := []struct {
+		name     string
+		terms    []string
+		expected bool
+	}{
+		{name: "no terms", terms: nil, expected: true},
+		{name: "present tag", terms: []string{"security"}, expected: true},
+		{name: "present tag in other case", terms: []string{"Security"}, expected: true},
+		{name: "missing tag", terms: []string{"performance"}, expected: false},
+		{name: "all present", terms: []string{"security", "compliance"}, expected: true},
+		{name: "one missing", terms: []string{"security", "performance"}, expected: false},
+		{name: "negated absent tag", terms: []string{"security", "!deprecated"}, expected: true},
+		{name: "negated present tag", terms: []string{"!compliance"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := req.MatchesTags(tt.terms); result != tt.expected {
+				t.Errorf("MatchesTags(%v) = %v, want %v", tt.terms, result, tt.expected)
+			}
+		})
+	}
+}
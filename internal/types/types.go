@@ -3,14 +3,19 @@ package types
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 // Project represents a collection of requirements for a Product Requirements Document
 type Project struct {
-	Name         string        `yaml:"name"`
-	Requirements []Requirement `yaml:"requirements,omitempty"`
+	Name         string              `json:"name" yaml:"name"`
+	LastUID      int                 `json:"last_uid,omitempty" yaml:"last_uid,omitempty"`
+	Transitions  map[string][]string `json:"transitions,omitempty" yaml:"transitions,omitempty"`
+	Requirements []Requirement       `json:"requirements,omitempty" yaml:"requirements,omitempty"`
 }
 
 // LoadProject loads a project from requirements.yaml file
@@ -30,10 +35,12 @@ func LoadProject() (*Project, error) {
 	return &project, nil
 }
 
-// Save saves the project to requirements.yaml file
+// Save saves the project to requirements.yaml file, assigning UIDs to requirements that lack one
 func (p *Project) Save() error {
 	const filename = "requirements.yaml"
 
+	p.AssignUIDs()
+
 	data, err := yaml.Marshal(p)
 	if err != nil {
 		return err
@@ -44,9 +51,25 @@ func (p *Project) Save() error {
 
 // Requirement represents a single requirement in a Product Requirements Document
 type Requirement struct {
-	ID       string        `yaml:"id"`
-	Text     string        `yaml:"text"`
-	Children []Requirement `yaml:"children,omitempty"`
+	ID         string        `json:"id" yaml:"id"`
+	UID        string        `json:"uid,omitempty" yaml:"uid,omitempty"`
+	Text       string        `json:"text" yaml:"text"`
+	Status     string        `json:"status,omitempty" yaml:"status,omitempty"`
+	Priority   string        `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Acceptance []string      `json:"acceptance,omitempty" yaml:"acceptance,omitempty"`
+	Tags       []string      `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Links      []Link        `json:"links,omitempty" yaml:"links,omitempty"`
+	Rationale  string        `json:"rationale,omitempty" yaml:"rationale,omitempty"`
+	Source     string        `json:"source,omitempty" yaml:"source,omitempty"`
+	Owner      string        `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Created    time.Time     `json:"created,omitzero" yaml:"created,omitempty"`
+	Updated    time.Time     `json:"updated,omitzero" yaml:"updated,omitempty"`
+	Children   []Requirement `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// Touch records the current time as the requirement's last update
+func (r *Requirement) Touch() {
+	r.Updated = time.Now().UTC().Truncate(time.Second)
 }
 
 // DisplayFormat returns the requirement in format "<id>: <text>"
@@ -54,16 +77,16 @@ func (r *Requirement) DisplayFormat() string {
 	return fmt.Sprintf("%s: %s", r.ID, r.Text)
 }
 
-// FindRequirement finds a requirement by ID in the project's requirement tree
+// FindRequirement finds a requirement by positional ID or UID in the project's requirement tree
 func (p *Project) FindRequirement(id string) *Requirement {
 	return findRequirement(p.Requirements, id)
 }
 
-// findRequirement recursively searches for a requirement by ID
+// findRequirement recursively searches for a requirement by positional ID or UID
 func findRequirement(requirements []Requirement, id string) *Requirement {
 	for i := range requirements {
 		req := requirements[i]
-		if req.ID == id {
+		if req.ID == id || (req.UID != "" && strings.EqualFold(req.UID, id)) {
 			return &requirements[i]
 		}
 		if found := findRequirement(req.Children, id); found != nil {
@@ -73,6 +96,88 @@ func findRequirement(requirements []Requirement, id string) *Requirement {
 	return nil
 }
 
+// uidPrefix is prepended to the counter of every requirement UID
+const uidPrefix = "REQ-"
+
+// NewUID returns the next unused requirement UID, e.g. "REQ-0042"
+func (p *Project) NewUID() string {
+	p.LastUID = max(p.LastUID, highestUID(p.Requirements))
+	p.LastUID++
+	return fmt.Sprintf("%s%04d", uidPrefix, p.LastUID)
+}
+
+// highestUID recursively finds the highest UID counter in use
+func highestUID(requirements []Requirement) int {
+	highest := 0
+	for _, req := range requirements {
+		if n, err := strconv.Atoi(strings.TrimPrefix(req.UID, uidPrefix)); err == nil && n > highest {
+			highest = n
+		}
+		highest = max(highest, highestUID(req.Children))
+	}
+	return highest
+}
+
+// IsUID reports whether value has the form of a requirement UID, e.g. "REQ-0042"
+func IsUID(value string) bool {
+	_, ok := uidNumber(value)
+	return ok
+}
+
+// ReserveUID makes sure NewUID never hands out uid, e.g. because it belongs to a requirement
+// that is about to be imported
+func (p *Project) ReserveUID(uid string) {
+	if n, ok := uidNumber(uid); ok {
+		p.LastUID = max(p.LastUID, n)
+	}
+}
+
+// uidNumber returns the counter of a requirement UID
+func uidNumber(uid string) (int, bool) {
+	if len(uid) <= len(uidPrefix) || !strings.EqualFold(uid[:len(uidPrefix)], uidPrefix) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(uid[len(uidPrefix):])
+	return n, err == nil && n > 0
+}
+
+// AssignUIDs gives every requirement without a UID a new one and returns how many were assigned
+func (p *Project) AssignUIDs() int {
+	return p.assignUIDs(p.Requirements)
+}
+
+// assignUIDs recursively assigns UIDs in tree order
+func (p *Project) assignUIDs(requirements []Requirement) int {
+	assigned := 0
+	for i := range requirements {
+		req := &requirements[i]
+		if req.UID == "" {
+			req.UID = p.NewUID()
+			assigned++
+		}
+		assigned += p.assignUIDs(req.Children)
+	}
+	return assigned
+}
+
+// Walk calls fn for every requirement in the project in tree order
+func (p *Project) Walk(fn func(req *Requirement)) {
+	walk(p.Requirements, fn)
+}
+
+// Walk calls fn for the requirement and each of its descendants in tree order
+func (r *Requirement) Walk(fn func(req *Requirement)) {
+	fn(r)
+	walk(r.Children, fn)
+}
+
+// walk recursively calls fn for each requirement and its descendants
+func walk(requirements []Requirement, fn func(req *Requirement)) {
+	for i := range requirements {
+		requirements[i].Walk(fn)
+	}
+}
+
 // GetBranches returns only the requirements that have children (branches, not leaves)
 func (p *Project) GetBranches() []Requirement {
 	return getBranches(p.Requirements)
@@ -89,3 +194,84 @@ func getBranches(requirements []Requirement) []Requirement {
 	}
 	return branches
 }
+
+// SetID changes the requirement ID and rewrites the ID prefix of all its descendants
+func (r *Requirement) SetID(id string) {
+	oldID := r.ID
+	r.ID = id
+	rewriteIDPrefix(r.Children, oldID, id)
+}
+
+// rewriteIDPrefix recursively replaces the oldPrefix of each requirement ID with newPrefix
+func rewriteIDPrefix(requirements []Requirement, oldPrefix, newPrefix string) {
+	for i := range requirements {
+		req := &requirements[i]
+		req.ID = newPrefix + strings.TrimPrefix(req.ID, oldPrefix)
+		rewriteIDPrefix(req.Children, oldPrefix, newPrefix)
+	}
+}
+
+// RemoveRequirement removes a requirement and its subtree from the project.
+// When renumber is true, the siblings that follow are renumbered to keep IDs gap-free.
+func (p *Project) RemoveRequirement(id string, renumber bool) (Requirement, bool) {
+	return removeRequirement(&p.Requirements, id, renumber)
+}
+
+// removeRequirement recursively searches for a requirement by ID and removes it from its siblings
+func removeRequirement(requirements *[]Requirement, id string, renumber bool) (Requirement, bool) {
+	reqs := *requirements
+	for i := range reqs {
+		if reqs[i].ID == id {
+			removed := reqs[i]
+			*requirements = append(reqs[:i], reqs[i+1:]...)
+			if renumber {
+				renumberFrom(*requirements, id, i)
+			}
+			return removed, true
+		}
+		if removed, ok := removeRequirement(&reqs[i].Children, id, renumber); ok {
+			return removed, true
+		}
+	}
+	return Requirement{}, false
+}
+
+// renumberFrom shifts the IDs of siblings starting at index start down by one to close the gap left by removedID
+func renumberFrom(siblings []Requirement, removedID string, start int) {
+	for i := start; i < len(siblings); i++ {
+		siblings[i].SetID(IDAfterRemoval(siblings[i].ID, removedID))
+	}
+}
+
+// ChildID returns the dot-notation ID of the n-th child of parentID ("" for top level)
+func ChildID(parentID string, n int) string {
+	if parentID == "" {
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%s.%d", parentID, n)
+}
+
+// IDAfterRemoval returns what id becomes once removedID is removed and its following siblings are renumbered
+func IDAfterRemoval(id, removedID string) string {
+	segments := strings.Split(id, ".")
+	removedSegments := strings.Split(removedID, ".")
+	depth := len(removedSegments)
+	if len(segments) < depth {
+		return id
+	}
+
+	for i := 0; i < depth-1; i++ {
+		if segments[i] != removedSegments[i] {
+			return id
+		}
+	}
+
+	n, err := strconv.Atoi(segments[depth-1])
+	removedN, removedErr := strconv.Atoi(removedSegments[depth-1])
+	if err != nil || removedErr != nil || n <= removedN {
+		return id
+	}
+
+	segments[depth-1] = strconv.Itoa(n - 1)
+	return strings.Join(segments, ".")
+}
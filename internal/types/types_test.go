@@ -225,4 +225,298 @@ func Test_findRequirement(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestRequirement_SetID(t *testing.T) {
+	req := Requirement{
+		ID:   "3",
+		Text: "Root",
+		Children: []Requirement{
+			{ID: "3.1", Text: "Child"},
+			{
+				ID:   "3.2",
+				Text: "Branch",
+				Children: []Requirement{
+					{ID: "3.2.1", Text: "Grandchild"},
+				},
+			},
+		},
+	}
+
+	req.SetID("1.4")
+
+	expected := Requirement{
+		ID:   "1.4",
+		Text: "Root",
+		Children: []Requirement{
+			{ID: "1.4.1", Text: "Child"},
+			{
+				ID:   "1.4.2",
+				Text: "Branch",
+				Children: []Requirement{
+					{ID: "1.4.2.1", Text: "Grandchild"},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(req, expected) {
+		t.Errorf("SetID() = %v, want %v", req, expected)
+	}
+}
+
+func TestProject_RemoveRequirement(t *testing.T) {
+	newProject := func() *Project {
+		return &Project{
+			Name: "test",
+			Requirements: []Requirement{
+				{ID: "1", Text: "Root 1"},
+				{
+					ID:   "2",
+					Text: "Root 2",
+					Children: []Requirement{
+						{ID: "2.1", Text: "Child 2.1"},
+						{
+							ID:   "2.2",
+							Text: "Child 2.2",
+							Children: []Requirement{
+								{ID: "2.2.1", Text: "Grandchild 2.2.1"},
+							},
+						},
+					},
+				},
+				{
+					ID:   "3",
+					Text: "Root 3",
+					Children: []Requirement{
+						{ID: "3.1", Text: "Child 3.1"},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		renumber bool
+		found    bool
+		expected []Requirement
+	}{
+		{
+			name:     "non-existent id",
+			id:       "999",
+			renumber: true,
+			found:    false,
+			expected: newProject().Requirements,
+		},
+		{
+			name:     "remove root and renumber following siblings",
+			id:       "1",
+			renumber: true,
+			found:    true,
+			expected: []Requirement{
+				{
+					ID:   "1",
+					Text: "Root 2",
+					Children: []Requirement{
+						{ID: "1.1", Text: "Child 2.1"},
+						{
+							ID:   "1.2",
+							Text: "Child 2.2",
+							Children: []Requirement{
+								{ID: "1.2.1", Text: "Grandchild 2.2.1"},
+							},
+						},
+					},
+				},
+				{
+					ID:   "2",
+					Text: "Root 3",
+					Children: []Requirement{
+						{ID: "2.1", Text: "Child 3.1"},
+					},
+				},
+			},
+		},
+		{
+			name:     "remove child and renumber following siblings",
+			id:       "2.1",
+			renumber: true,
+			found:    true,
+			expected: []Requirement{
+				{ID: "1", Text: "Root 1"},
+				{
+					ID:   "2",
+					Text: "Root 2",
+					Children: []Requirement{
+						{
+							ID:   "2.1",
+							Text: "Child 2.2",
+							Children: []Requirement{
+								{ID: "2.1.1", Text: "Grandchild 2.2.1"},
+							},
+						},
+					},
+				},
+				{
+					ID:   "3",
+					Text: "Root 3",
+					Children: []Requirement{
+						{ID: "3.1", Text: "Child 3.1"},
+					},
+				},
+			},
+		},
+		{
+			name:     "remove child keeping ids",
+			id:       "2.1",
+			renumber: false,
+			found:    true,
+			expected: []Requirement{
+				{ID: "1", Text: "Root 1"},
+				{
+					ID:   "2",
+					Text: "Root 2",
+					Children: []Requirement{
+						{
+							ID:   "2.2",
+							Text: "Child 2.2",
+							Children: []Requirement{
+								{ID: "2.2.1", Text: "Grandchild 2.2.1"},
+							},
+						},
+					},
+				},
+				{
+					ID:   "3",
+					Text: "Root 3",
+					Children: []Requirement{
+						{ID: "3.1", Text: "Child 3.1"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := newProject()
+			removed, found := project.RemoveRequirement(tt.id, tt.renumber)
+
+			if found != tt.found {
+				t.Fatalf("RemoveRequirement() found = %v, want %v", found, tt.found)
+			}
+
+			if found && removed.ID != tt.id {
+				t.Errorf("RemoveRequirement() removed = %v, want ID %s", removed, tt.id)
+			}
+
+			if !reflect.DeepEqual(project.Requirements, tt.expected) {
+				t.Errorf("RemoveRequirement() requirements = %v, want %v", project.Requirements, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIDAfterRemoval(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		removedID string
+		expected  string
+	}{
+		{name: "preceding sibling", id: "1", removedID: "2", expected: "1"},
+		{name: "following sibling", id: "3", removedID: "2", expected: "2"},
+		{name: "descendant of following sibling", id: "3.1.2", removedID: "2", expected: "2.1.2"},
+		{name: "following nested sibling", id: "1.4", removedID: "1.2", expected: "1.3"},
+		{name: "different branch", id: "2.4", removedID: "1.2", expected: "2.4"},
+		{name: "ancestor of removed", id: "1", removedID: "1.2", expected: "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IDAfterRemoval(tt.id, tt.removedID)
+			if result != tt.expected {
+				t.Errorf("IDAfterRemoval(%q, %q) = %q, want %q", tt.id, tt.removedID, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProject_AssignUIDs(t *testing.T) {
+	project := &Project{
+		Name: "test",
+		Requirements: []Requirement{
+			{ID: "1", Text: "Root 1"},
+			{
+				ID:   "2",
+				UID:  "REQ-0007",
+				Text: "Root 2",
+				Children: []Requirement{
+					{ID: "2.1", Text: "Child 2.1"},
+				},
+			},
+		},
+	}
+
+	assigned := project.AssignUIDs()
+	if assigned != 2 {
+		t.Errorf("AssignUIDs() = %d, want 2", assigned)
+	}
+
+	expected := []Requirement{
+		{ID: "1", UID: "REQ-0008", Text: "Root 1"},
+		{
+			ID:   "2",
+			UID:  "REQ-0007",
+			Text: "Root 2",
+			Children: []Requirement{
+				{ID: "2.1", UID: "REQ-0009", Text: "Child 2.1"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(project.Requirements, expected) {
+		t.Errorf("AssignUIDs() requirements = %v, want %v", project.Requirements, expected)
+	}
+
+	if project.AssignUIDs() != 0 {
+		t.Errorf("AssignUIDs() reassigned existing UIDs")
+	}
+
+	if found := project.FindRequirement("req-0009"); found == nil || found.ID != "2.1" {
+		t.Errorf("FindRequirement() by UID = %v, want 2.1", found)
+	}
+}
+
+func TestIsUID(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"REQ-0042", true},
+		{"req-7", true},
+		{"REQ-", false},
+		{"REQ-00x1", false},
+		{"1.2", false},
+		{"DOORS-12", false},
+	}
+
+	for _, tt := range tests {
+		if result := IsUID(tt.value); result != tt.expected {
+			t.Errorf("IsUID(%q) = %v, want %v", tt.value, result, tt.expected)
+		}
+	}
+}
+
+func TestProject_ReserveUID(t *testing.T) {
+	project := &Project{Name: "test", LastUID: 3}
+
+	project.ReserveUID("REQ-0010")
+	project.ReserveUID("REQ-0005")
+	project.ReserveUID("not a uid")
+
+	if uid := project.NewUID(); uid != "REQ-0011" {
+		t.Errorf("NewUID() after ReserveUID() = %q, want REQ-0011", uid)
+	}
+}